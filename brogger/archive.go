@@ -0,0 +1,182 @@
+package brogger
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aybabtme/log"
+)
+
+// archivePrefix is where posts are listed by year and month, as
+// `/archive/<year>/<month>/`.
+const archivePrefix = "/archive/"
+
+// archiveYear lists the posts of a year, month by month.
+type archiveYear struct {
+	Year   int
+	Count  int             // Posts in that year
+	Months []*archiveMonth // Most recent first
+}
+
+// archiveMonth lists the posts of a month.
+type archiveMonth struct {
+	Year  int
+	Month time.Month
+	Count int     // Posts in that month
+	Posts []*post // Most recent first
+}
+
+// URL of the archive of the year.
+func (y *archiveYear) URL() string {
+	return archiveURL(y.Year, 0)
+}
+
+// URL of the archive of the month.
+func (m *archiveMonth) URL() string {
+	return archiveURL(m.Year, m.Month)
+}
+
+// archiveURL is the URL of the archive of `year` and `month`. A zero `month`
+// is the whole year, a zero `year` the whole archive.
+func archiveURL(year int, month time.Month) string {
+	switch {
+	case year == 0:
+		return archivePrefix
+	case month == 0:
+		return fmt.Sprintf("%s%04d/", archivePrefix, year)
+	}
+	return fmt.Sprintf("%s%04d/%02d/", archivePrefix, year, int(month))
+}
+
+// archive groups posts by year then month, by language. The empty language
+// holds the posts of all languages.
+type archive map[string][]*archiveYear
+
+// newArchive groups `posts`, which must be in most recent order.
+func newArchive(posts []*post) archive {
+	arc := make(archive)
+	for _, p := range posts {
+		arc[""] = addToArchive(arc[""], p)
+		if p.Language != "" {
+			arc[p.Language] = addToArchive(arc[p.Language], p)
+		}
+	}
+	return arc
+}
+
+func addToArchive(years []*archiveYear, p *post) []*archiveYear {
+	year, month := p.Date.Year(), p.Date.Month()
+
+	if len(years) == 0 || years[len(years)-1].Year != year {
+		years = append(years, &archiveYear{Year: year})
+	}
+	y := years[len(years)-1]
+	y.Count++
+
+	if len(y.Months) == 0 || y.Months[len(y.Months)-1].Month != month {
+		y.Months = append(y.Months, &archiveMonth{Year: year, Month: month})
+	}
+	m := y.Months[len(y.Months)-1]
+	m.Count++
+	m.Posts = append(m.Posts, p)
+
+	return years
+}
+
+// period returns the part of the archive in `lang` covering `year` and
+// `month`, zero meaning all of them, or false if no post was published in
+// that period.
+func (a archive) period(lang string, year int, month time.Month) ([]*archiveYear, bool) {
+	years := a[lang]
+	if year == 0 {
+		return years, true
+	}
+	for _, y := range years {
+		if y.Year != year {
+			continue
+		}
+		if month == 0 {
+			return []*archiveYear{y}, true
+		}
+		for _, m := range y.Months {
+			if m.Month == month {
+				return []*archiveYear{{Year: year, Count: m.Count, Months: []*archiveMonth{m}}}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// parseArchivePath reads the year and month of an archive URL, zero when
+// they are absent.
+func parseArchivePath(urlpath string) (int, time.Month, bool) {
+	rest := strings.Trim(strings.TrimPrefix(urlpath, archivePrefix), "/")
+	if rest == "" {
+		return 0, 0, true
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) > 2 {
+		return 0, 0, false
+	}
+	year, err := strconv.Atoi(parts[0])
+	if err != nil || year < 1 {
+		return 0, 0, false
+	}
+	if len(parts) == 1 {
+		return year, 0, true
+	}
+	month, err := strconv.Atoi(parts[1])
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, false
+	}
+	return year, time.Month(month), true
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// HandlerFuncs
+////////////////////////////////////////////////////////////////////////////////
+
+// archiveContent is the data rendered by the archive template for `year`
+// and `month`, or false if there is nothing to list for that period.
+func (b *Brog) archiveContent(lang string, year int, month time.Month) (appContent, bool) {
+	years, ok := b.postMngr.GetArchive(lang, year, month)
+	if !ok {
+		return appContent{}, false
+	}
+
+	data := b.baseContent(lang)
+	data.Archive = years
+	switch {
+	case month != 0:
+		data.Period = fmt.Sprintf("%s %d", month, year)
+	case year != 0:
+		data.Period = strconv.Itoa(year)
+	}
+	return data, true
+}
+
+func (b *Brog) archiveFunc(rw http.ResponseWriter, req *http.Request) {
+
+	lang, _ := b.extractLanguage(req)
+
+	reqPath := strings.SplitN(req.RequestURI, "?", 2)[0]
+	year, month, ok := parseArchivePath(reqPath)
+	if !ok {
+		b.notFound(rw, req)
+		return
+	}
+
+	data, ok := b.archiveContent(lang, year, month)
+	if !ok {
+		b.notFound(rw, req)
+		return
+	}
+
+	if err := b.render(rw, req, b.tmplMngr.DoWithArchive, data); err != nil {
+		log.Err(err).KV("archive.period", data.Period).Error("couldn't render archive template")
+	}
+}
@@ -0,0 +1,67 @@
+package brogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArchive(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	posts := []*post{
+		{id: "d", Language: "en", Date: date(2015, 1, 3)},
+		{id: "c", Language: "fr", Date: date(2014, 3, 9)},
+		{id: "b", Language: "en", Date: date(2014, 3, 2)},
+		{id: "a", Language: "en", Date: date(2014, 1, 5)},
+	}
+	arc := newArchive(posts)
+
+	years, ok := arc.period("", 0, 0)
+	if !ok || len(years) != 2 || years[0].Year != 2015 || years[1].Year != 2014 {
+		t.Fatalf("Years aren't listed most recent first. Got %v", years)
+	}
+	if years[1].Count != 3 || len(years[1].Months) != 2 || years[1].Months[0].Count != 2 {
+		t.Error("Posts aren't counted by year and month")
+	}
+
+	years, ok = arc.period("en", 2014, 3)
+	if !ok || len(years) != 1 || years[0].Count != 1 || years[0].Months[0].Posts[0].id != "b" {
+		t.Error("Archive of a month isn't filtered by language")
+	}
+
+	if _, ok := arc.period("fr", 2015, 0); ok {
+		t.Error("Archive has a year without posts in that language")
+	}
+	if _, ok := arc.period("", 2014, 2); ok {
+		t.Error("Archive has a month without posts")
+	}
+}
+
+func TestParseArchivePath(t *testing.T) {
+	tests := []struct {
+		urlpath string
+		year    int
+		month   time.Month
+		ok      bool
+	}{
+		{"/archive/", 0, 0, true},
+		{"/archive/2014/", 2014, 0, true},
+		{"/archive/2014/03/", 2014, time.March, true},
+		{"/archive/2014/3", 2014, time.March, true},
+		{"/archive/2014/13/", 0, 0, false},
+		{"/archive/twenty/", 0, 0, false},
+		{"/archive/2014/03/01/", 0, 0, false},
+	}
+	for _, tt := range tests {
+		year, month, ok := parseArchivePath(tt.urlpath)
+		if year != tt.year || month != tt.month || ok != tt.ok {
+			t.Errorf("Parsing '%s', expected %d %v %v, got %d %v %v",
+				tt.urlpath, tt.year, tt.month, tt.ok, year, month, ok)
+		}
+	}
+
+	if archiveURL(2014, time.March) != "/archive/2014/03/" {
+		t.Error("Archive URL of a month isn't padded. Got", archiveURL(2014, time.March))
+	}
+}
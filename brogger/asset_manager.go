@@ -0,0 +1,290 @@
+package brogger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aybabtme/log"
+)
+
+const (
+	// assetsPrefix is where the files of the asset path are served.
+	assetsPrefix = "/assets/"
+	// immutableCacheControl lets browsers keep fingerprinted assets for a
+	// year without asking again: once changed, they get another URL.
+	immutableCacheControl = "public, max-age=31536000, immutable"
+)
+
+// assetManager fingerprints the files of the asset path with a hash of
+// their content, so that they can be cached for good under URLs that
+// change with them. It keeps the content it hashed, which is what's served
+// under the fingerprinted URLs, along with the previous content of the
+// assets that changed: pages rendered before the change still point to it.
+type assetManager struct {
+	brog *Brog
+	path string
+
+	watcher watcher         // Listens on `path`
+	dirs    map[string]bool // Directories under `path` being watched
+	die     chan struct{}   // To kill the watcher goroutine
+
+	mu            sync.RWMutex          // Locks everything below
+	fingerprinted map[string]string     // Fingerprinted name of the assets, by name
+	files         map[string]*assetFile // Content of the assets, by fingerprinted name
+}
+
+// assetFile is the content of an asset, as it was fingerprinted.
+type assetFile struct {
+	name    string
+	data    []byte
+	modTime time.Time
+}
+
+// newAssetManager fingerprints the assets found at `assetPath`, without
+// watching them for changes.
+func newAssetManager(brog *Brog, assetPath string) (*assetManager, error) {
+	assetMngr := &assetManager{
+		brog: brog,
+		path: assetPath,
+		dirs: make(map[string]bool),
+		die:  make(chan struct{}),
+	}
+
+	if err := assetMngr.fingerprintAll(); err != nil {
+		return nil, fmt.Errorf("fingerprinting assets, %v", err)
+	}
+
+	return assetMngr, nil
+}
+
+func startAssetManager(brog *Brog, assetPath string) (*assetManager, error) {
+
+	assetMngr, err := newAssetManager(brog, assetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	assetMngr.watcher, err = brog.newWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("getting asset watcher, %v", err)
+	}
+
+	if err := assetMngr.watchForChanges(assetPath); err != nil {
+		return nil, fmt.Errorf("starting watch for changes on '%s', %v", assetPath, err)
+	}
+
+	return assetMngr, nil
+}
+
+func (a *assetManager) Close() error {
+	if a.watcher == nil {
+		// Never started watching
+		return nil
+	}
+	a.die <- struct{}{}
+	return a.watcher.Close()
+}
+
+// fingerprintAll hashes all the assets again. The content of those that
+// changed since the last time is kept under their previous fingerprint.
+func (a *assetManager) fingerprintAll() error {
+	fingerprinted := make(map[string]string)
+	files := make(map[string]*assetFile)
+	err := filepath.Walk(a.path, func(fullpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relpath, err := filepath.Rel(a.path, fullpath)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(fullpath)
+		if err != nil {
+			return fmt.Errorf("reading '%s', %v", fullpath, err)
+		}
+		name := filepath.ToSlash(relpath)
+		fingerprint := fingerprintName(name, hashData(data))
+		fingerprinted[name] = fingerprint
+		files[fingerprint] = &assetFile{name: name, data: data, modTime: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing directory '%s', %v", a.path, err)
+	}
+
+	a.mu.Lock()
+	for _, fingerprint := range a.fingerprinted {
+		if _, ok := files[fingerprint]; !ok {
+			files[fingerprint] = a.files[fingerprint]
+		}
+	}
+	a.fingerprinted = fingerprinted
+	a.files = files
+	a.mu.Unlock()
+	return nil
+}
+
+func hashData(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// fingerprintName puts `hash` before the extension of `name`, so that
+// `css/brog.css` becomes `css/brog.<hash>.css`.
+func fingerprintName(name, hash string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// Fingerprinted gives the fingerprinted name of asset `name`, if there's
+// such an asset.
+func (a *assetManager) Fingerprinted(name string) (string, bool) {
+	if a == nil {
+		return "", false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	fingerprint, ok := a.fingerprinted[name]
+	return fingerprint, ok
+}
+
+// file gives the content of the asset whose fingerprinted name is
+// `fingerprint`, if it's the current or the previous content of an asset.
+func (a *assetManager) file(fingerprint string) (*assetFile, bool) {
+	if a == nil {
+		return nil, false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	file, ok := a.files[fingerprint]
+	return file, ok
+}
+
+// copyFingerprinted writes the assets under their fingerprinted name in
+// `dstDir`, for the URLs of built pages to point to them.
+func (a *assetManager) copyFingerprinted(dstDir string) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, fingerprint := range a.fingerprinted {
+		dst := filepath.Join(dstDir, filepath.FromSlash(fingerprint))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("creating directory for '%s', %v", dst, err)
+		}
+		if err := ioutil.WriteFile(dst, a.files[fingerprint].data, 0644); err != nil {
+			return fmt.Errorf("writing '%s', %v", dst, err)
+		}
+	}
+	return nil
+}
+
+func (a *assetManager) watchForChanges(dirname string) error {
+	if err := a.watchTree(dirname); err != nil {
+		return err
+	}
+
+	go func() {
+		ll := log.KV("dir.name", dirname)
+		queue := newEventQueue(a.processAssetEvent)
+		for {
+			select {
+			case ev := <-a.watcher.Events():
+				queue.add(ev)
+			case <-queue.ready():
+				queue.flush()
+			case err := <-a.watcher.Errors():
+				ll.Err(err).Error("error watching assets")
+			case <-a.die:
+				queue.stop()
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// watchTree watches `dirname` and all the directories under it.
+func (a *assetManager) watchTree(dirname string) error {
+	return filepath.Walk(dirname, func(fullpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if err := a.watcher.Watch(fullpath); err != nil {
+			return fmt.Errorf("watching directory '%s', %v", fullpath, err)
+		}
+		a.dirs[fullpath] = true
+		return nil
+	})
+}
+
+// processAssetEvent fingerprints the assets again, and has the pages
+// rendered again to point to their new URLs.
+func (a *assetManager) processAssetEvent(ev fileEvent, _ bool) error {
+	ll := log.KV("file.name", ev.name)
+
+	switch ev.op {
+	case fileCreated:
+		if info, err := os.Stat(ev.name); err == nil && info.IsDir() {
+			if err := a.watchTree(ev.name); err != nil {
+				ll.Err(err).Error("can't watch new directory")
+			}
+		}
+	case fileRenamed, fileDeleted:
+		prefix := ev.name + string(os.PathSeparator)
+		for dir := range a.dirs {
+			if dir == ev.name || strings.HasPrefix(dir, prefix) {
+				// Fails if the directory is already gone, nothing to do then
+				_ = a.watcher.RemoveWatch(dir)
+				delete(a.dirs, dir)
+			}
+		}
+	}
+
+	if err := a.fingerprintAll(); err != nil {
+		return err
+	}
+	ll.Info("asset changed, fingerprinted assets again")
+	a.brog.contentChanged()
+	return nil
+}
+
+// assetURL is the `asset` template function. It gives the URL of asset
+// `name`, relative to the asset path, fingerprinted if the asset exists.
+func (b *Brog) assetURL(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if fingerprint, ok := b.assetMngr.Fingerprinted(name); ok {
+		return assetsPrefix + fingerprint
+	}
+	return assetsPrefix + name
+}
+
+// assetHandler serves the assets with `h`, and those requested by their
+// fingerprinted name with the content that was fingerprinted, with headers
+// that let them be cached for good. The asset path must be stripped from
+// the URL already.
+func (b *Brog) assetHandler(h http.Handler) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		file, ok := b.assetMngr.file(strings.TrimPrefix(req.URL.Path, "/"))
+		if !ok {
+			h.ServeHTTP(rw, req)
+			return
+		}
+		rw.Header().Set("Cache-Control", immutableCacheControl)
+		http.ServeContent(rw, req, path.Base(file.name), file.modTime, bytes.NewReader(file.data))
+	})
+}
@@ -0,0 +1,96 @@
+package brogger
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestAssetFingerprints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brog-assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cssDir := filepath.Join(dir, "css")
+	if err := os.MkdirAll(cssDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(cssDir, "brog.css")
+	if err := ioutil.WriteFile(filename, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := SetUpDefaultBrog()
+	if got := b.assetURL("css/brog.css"); got != "/assets/css/brog.css" {
+		t.Errorf("Without assets, expected the plain URL, got %q", got)
+	}
+
+	b.assetMngr, err = newAssetManager(b, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := b.assetURL("css/brog.css")
+	if !regexp.MustCompile(`^/assets/css/brog\.[0-9a-f]+\.css$`).MatchString(url) {
+		t.Fatalf("Expected a fingerprinted URL, got %q", url)
+	}
+	if got := b.assetURL("/css/missing.css"); got != "/assets/css/missing.css" {
+		t.Errorf("Expected the plain URL of a missing asset, got %q", got)
+	}
+
+	h := http.StripPrefix(assetsPrefix, b.assetHandler(http.FileServer(http.Dir(dir))))
+	get := func(uri string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", uri, nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get(url)
+	if rec.Code != http.StatusOK || rec.Body.String() != "body {}" {
+		t.Errorf("Fingerprinted asset not served, got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Cache-Control") != immutableCacheControl {
+		t.Errorf("Fingerprinted asset isn't immutable, got %q", rec.Header().Get("Cache-Control"))
+	}
+	rec = get("/assets/css/brog.css")
+	if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "" {
+		t.Error("Asset requested by its name isn't served as before")
+	}
+
+	if err := ioutil.WriteFile(filename, []byte("body { color: red }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Until the change is seen, the content that was fingerprinted is served
+	if rec := get(url); rec.Body.String() != "body {}" {
+		t.Errorf("Fingerprinted asset served with content that changed since, got %q", rec.Body.String())
+	}
+	if err := b.assetMngr.processAssetEvent(fileEvent{name: filename, op: fileModified}, false); err != nil {
+		t.Fatal(err)
+	}
+	newURL := b.assetURL("css/brog.css")
+	if newURL == url {
+		t.Fatal("Fingerprint didn't change with the asset")
+	}
+	if rec := get(newURL); rec.Code != http.StatusOK || rec.Body.String() != "body { color: red }" {
+		t.Errorf("New fingerprint not served, got %d %q", rec.Code, rec.Body.String())
+	}
+	// Pages rendered before the change still work
+	if rec := get(url); rec.Code != http.StatusOK || rec.Body.String() != "body {}" {
+		t.Errorf("Previous fingerprint not served with the previous content, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	if err := ioutil.WriteFile(filename, []byte("body { color: blue }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.assetMngr.processAssetEvent(fileEvent{name: filename, op: fileModified}, false); err != nil {
+		t.Fatal(err)
+	}
+	if rec := get(url); rec.Code != http.StatusNotFound {
+		t.Errorf("Fingerprint two changes old still served, got %d", rec.Code)
+	}
+}
@@ -11,8 +11,13 @@ import (
 const (
 	// ConfigFilename where to find the Brog config file.
 	ConfigFilename = "brog_config.json"
-	jsPath         = "js" + string(os.PathSeparator)
-	cssPath        = "css" + string(os.PathSeparator)
+	// RedirectsFilename where Brog remembers the URLs posts moved from.
+	RedirectsFilename = "redirects.json"
+	// RobotsFilename replaces the generated robots.txt if it exists.
+	RobotsFilename = "robots.txt"
+
+	jsPath  = "js" + string(os.PathSeparator)
+	cssPath = "css" + string(os.PathSeparator)
 )
 
 // Base templates
@@ -38,6 +43,12 @@ var allTemplates = map[string]packed{
 	indexTmplName:      {indexTmplName, DefaultTemplatePath, baseTemplatesIndexGohtml},
 	postTmplName:       {postTmplName, DefaultTemplatePath, baseTemplatesPostGohtml},
 	langSelectTmplName: {langSelectTmplName, DefaultTemplatePath, baseTemplatesLangselectGohtml},
+	tagTmplName:        {tagTmplName, DefaultTemplatePath, baseTemplatesTagGohtml},
+	searchTmplName:     {searchTmplName, DefaultTemplatePath, baseTemplatesSearchGohtml},
+	archiveTmplName:    {archiveTmplName, DefaultTemplatePath, baseTemplatesArchiveGohtml},
+	seriesTmplName:     {seriesTmplName, DefaultTemplatePath, baseTemplatesSeriesGohtml},
+	notFoundTmplName:   {notFoundTmplName, DefaultTemplatePath, baseTemplates404Gohtml},
+	errorTmplName:      {errorTmplName, DefaultTemplatePath, baseTemplates500Gohtml},
 	styleTmplName:      {styleTmplName, DefaultTemplatePath, baseTemplatesStyleGohtml},
 	jsTmplName:         {jsTmplName, DefaultTemplatePath, baseTemplatesJavascriptGohtml},
 	headerTmplName:     {headerTmplName, DefaultTemplatePath, baseTemplatesHeaderGohtml},
@@ -86,7 +97,13 @@ func CopyBrogBinaries() []error {
 	config := newDefaultConfig()
 	errs := []error{}
 
-	err := config.persistToFile(ConfigFilename)
+	secret, err := newPreviewSecret()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	config.PreviewSecret = secret
+
+	err = config.persistToFile(ConfigFilename)
 	if err != nil {
 		errs = append(errs, fmt.Errorf("persisting config file, %v", err))
 	}
@@ -119,12 +136,18 @@ func CopyBlankToFilename(conf *Config, filename string, filenamepath string) err
 	}
 	fullpath := filepath.Clean(filenamepath) + string(os.PathSeparator) + filename + conf.PostFileExt
 
+	// Posts can be created in subdirectories, like `docs/install`
+	if err := os.MkdirAll(filepath.Dir(fullpath), 0755); err != nil {
+		return fmt.Errorf("creating directory for '%s', %v", fullpath, err)
+	}
+
 	post := post{
-		Title:     filename,
+		Title:     filepath.Base(filename),
 		Date:      time.Now(),
 		Invisible: true,
 		Language:  "en",
 		Content:   "Write markdown content here.",
+		format:    conf.FrontMatter,
 	}
 
 	return post.exportToFile(fullpath)
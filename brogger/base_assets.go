@@ -173,7 +173,49 @@ var baseAssetsCssBrogCss = []byte{
 	0x20, 0x20, 0x20, 0x62, 0x6f, 0x72, 0x64, 0x65,
 	0x72, 0x2d, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73,
 	0x3a, 0x20, 0x35, 0x70, 0x78, 0x3b, 0x0a, 0x7d,
-	0x0a, 0x0a, 0x0a, 0x0a,
+	0x0a, 0x0a, 0x2e, 0x64, 0x72, 0x61, 0x66, 0x74,
+	0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x3a, 0x20, 0x23, 0x62,
+	0x39, 0x34, 0x61, 0x34, 0x38, 0x3b, 0x0a, 0x20,
+	0x20, 0x20, 0x20, 0x74, 0x65, 0x78, 0x74, 0x2d,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
+	0x6d, 0x3a, 0x20, 0x75, 0x70, 0x70, 0x65, 0x72,
+	0x63, 0x61, 0x73, 0x65, 0x3b, 0x0a, 0x7d, 0x0a,
+	0x0a, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72,
+	0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x6d,
+	0x61, 0x72, 0x67, 0x69, 0x6e, 0x2d, 0x6c, 0x65,
+	0x66, 0x74, 0x3a, 0x20, 0x30, 0x2e, 0x33, 0x65,
+	0x6d, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x3a, 0x20, 0x23, 0x39,
+	0x39, 0x39, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20,
+	0x74, 0x65, 0x78, 0x74, 0x2d, 0x64, 0x65, 0x63,
+	0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x3a,
+	0x20, 0x6e, 0x6f, 0x6e, 0x65, 0x3b, 0x0a, 0x20,
+	0x20, 0x20, 0x20, 0x76, 0x69, 0x73, 0x69, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x3a, 0x20, 0x68,
+	0x69, 0x64, 0x64, 0x65, 0x6e, 0x3b, 0x0a, 0x7d,
+	0x0a, 0x0a, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x6f,
+	0x72, 0x3a, 0x3a, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x3a, 0x20,
+	0x22, 0x23, 0x22, 0x3b, 0x0a, 0x7d, 0x0a, 0x0a,
+	0x68, 0x31, 0x3a, 0x68, 0x6f, 0x76, 0x65, 0x72,
+	0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72,
+	0x2c, 0x20, 0x68, 0x32, 0x3a, 0x68, 0x6f, 0x76,
+	0x65, 0x72, 0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68,
+	0x6f, 0x72, 0x2c, 0x20, 0x68, 0x33, 0x3a, 0x68,
+	0x6f, 0x76, 0x65, 0x72, 0x20, 0x2e, 0x61, 0x6e,
+	0x63, 0x68, 0x6f, 0x72, 0x2c, 0x0a, 0x68, 0x34,
+	0x3a, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x20, 0x2e,
+	0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x2c, 0x20,
+	0x68, 0x35, 0x3a, 0x68, 0x6f, 0x76, 0x65, 0x72,
+	0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72,
+	0x2c, 0x20, 0x68, 0x36, 0x3a, 0x68, 0x6f, 0x76,
+	0x65, 0x72, 0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68,
+	0x6f, 0x72, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20,
+	0x20, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x3a, 0x20, 0x76, 0x69, 0x73,
+	0x69, 0x62, 0x6c, 0x65, 0x3b, 0x0a, 0x7d, 0x0a,
 }
 
 var baseAssetsCssGithubCss = []byte{
@@ -3570,6 +3612,69 @@ var baseAssetsJsHighlightMinJs = []byte{
 	0x73, 0x29, 0x3b,
 }
 
+var baseTemplates404Gohtml = []byte{
+	0x7b, 0x7b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65,
+	0x20, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x7d, 0x7d, 0x0a, 0x3c, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x3c,
+	0x68, 0x32, 0x3e, 0x50, 0x61, 0x67, 0x65, 0x20,
+	0x6e, 0x6f, 0x74, 0x20, 0x66, 0x6f, 0x75, 0x6e,
+	0x64, 0x3c, 0x2f, 0x68, 0x32, 0x3e, 0x0a, 0x3c,
+	0x70, 0x3e, 0x54, 0x68, 0x65, 0x72, 0x65, 0x27,
+	0x73, 0x20, 0x6e, 0x6f, 0x74, 0x68, 0x69, 0x6e,
+	0x67, 0x20, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x20,
+	0x49, 0x74, 0x20, 0x6d, 0x61, 0x79, 0x20, 0x68,
+	0x61, 0x76, 0x65, 0x20, 0x6d, 0x6f, 0x76, 0x65,
+	0x64, 0x2c, 0x20, 0x6f, 0x72, 0x20, 0x6e, 0x65,
+	0x76, 0x65, 0x72, 0x20, 0x65, 0x78, 0x69, 0x73,
+	0x74, 0x65, 0x64, 0x2e, 0x3c, 0x2f, 0x70, 0x3e,
+	0x0a, 0x3c, 0x70, 0x3e, 0x54, 0x72, 0x79, 0x20,
+	0x74, 0x68, 0x65, 0x20, 0x3c, 0x61, 0x20, 0x68,
+	0x72, 0x65, 0x66, 0x3d, 0x22, 0x2f, 0x22, 0x3e,
+	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x20, 0x70,
+	0x6f, 0x73, 0x74, 0x73, 0x3c, 0x2f, 0x61, 0x3e,
+	0x2c, 0x20, 0x74, 0x68, 0x65, 0x20, 0x3c, 0x61,
+	0x20, 0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x2f,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x2f,
+	0x22, 0x3e, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x3c, 0x2f, 0x61, 0x3e, 0x20, 0x6f, 0x72,
+	0x20, 0x61, 0x20, 0x3c, 0x61, 0x20, 0x68, 0x72,
+	0x65, 0x66, 0x3d, 0x22, 0x2f, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x22, 0x3e, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x3c, 0x2f, 0x61, 0x3e, 0x2e,
+	0x3c, 0x2f, 0x70, 0x3e, 0x0a, 0x3c, 0x2f, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a,
+	0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a,
+}
+
+var baseTemplates500Gohtml = []byte{
+	0x7b, 0x7b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65,
+	0x20, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x7d, 0x7d, 0x0a, 0x3c, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x3c,
+	0x68, 0x32, 0x3e, 0x53, 0x6f, 0x6d, 0x65, 0x74,
+	0x68, 0x69, 0x6e, 0x67, 0x20, 0x77, 0x65, 0x6e,
+	0x74, 0x20, 0x77, 0x72, 0x6f, 0x6e, 0x67, 0x3c,
+	0x2f, 0x68, 0x32, 0x3e, 0x0a, 0x3c, 0x70, 0x3e,
+	0x54, 0x68, 0x69, 0x73, 0x20, 0x70, 0x61, 0x67,
+	0x65, 0x20, 0x63, 0x61, 0x6e, 0x27, 0x74, 0x20,
+	0x62, 0x65, 0x20, 0x73, 0x68, 0x6f, 0x77, 0x6e,
+	0x20, 0x72, 0x69, 0x67, 0x68, 0x74, 0x20, 0x6e,
+	0x6f, 0x77, 0x2c, 0x20, 0x70, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x20, 0x74, 0x72, 0x79, 0x20, 0x61,
+	0x67, 0x61, 0x69, 0x6e, 0x20, 0x69, 0x6e, 0x20,
+	0x61, 0x20, 0x6d, 0x6f, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x3c, 0x2f, 0x70, 0x3e, 0x0a, 0x7b, 0x7b,
+	0x77, 0x69, 0x74, 0x68, 0x20, 0x2e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x7d, 0x7d, 0x3c, 0x70, 0x72,
+	0x65, 0x3e, 0x7b, 0x7b, 0x68, 0x74, 0x6d, 0x6c,
+	0x20, 0x2e, 0x7d, 0x7d, 0x3c, 0x2f, 0x70, 0x72,
+	0x65, 0x3e, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d,
+	0x7d, 0x0a, 0x3c, 0x2f, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x7b, 0x7b, 0x65,
+	0x6e, 0x64, 0x7d, 0x7d, 0x0a,
+}
+
 var baseTemplatesApplicationGohtml = []byte{
 	0x3c, 0x21, 0x64, 0x6f, 0x63, 0x74, 0x79, 0x70,
 	0x65, 0x20, 0x68, 0x74, 0x6d, 0x6c, 0x3e, 0x0a,
@@ -3606,33 +3711,124 @@ var baseTemplatesApplicationGohtml = []byte{
 	0x2f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x3e, 0x0a,
 	0x20, 0x20, 0x20, 0x20, 0x7b, 0x7b, 0x65, 0x6e,
 	0x64, 0x7d, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20,
+	0x3c, 0x6c, 0x69, 0x6e, 0x6b, 0x20, 0x72, 0x65,
+	0x6c, 0x3d, 0x22, 0x61, 0x6c, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x74, 0x65, 0x22, 0x20, 0x74, 0x79,
+	0x70, 0x65, 0x3d, 0x22, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x61, 0x74, 0x6f, 0x6d, 0x2b, 0x78, 0x6d, 0x6c,
+	0x22, 0x20, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x3d,
+	0x22, 0x41, 0x74, 0x6f, 0x6d, 0x22, 0x20, 0x68,
+	0x72, 0x65, 0x66, 0x3d, 0x22, 0x2f, 0x66, 0x65,
+	0x65, 0x64, 0x2e, 0x61, 0x74, 0x6f, 0x6d, 0x22,
+	0x3e, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x3c, 0x6c,
+	0x69, 0x6e, 0x6b, 0x20, 0x72, 0x65, 0x6c, 0x3d,
+	0x22, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x74, 0x65, 0x22, 0x20, 0x74, 0x79, 0x70, 0x65,
+	0x3d, 0x22, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x72, 0x73,
+	0x73, 0x2b, 0x78, 0x6d, 0x6c, 0x22, 0x20, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x3d, 0x22, 0x52, 0x53,
+	0x53, 0x22, 0x20, 0x68, 0x72, 0x65, 0x66, 0x3d,
+	0x22, 0x2f, 0x66, 0x65, 0x65, 0x64, 0x2e, 0x72,
+	0x73, 0x73, 0x22, 0x3e, 0x0a, 0x20, 0x20, 0x20,
+	0x20, 0x7b, 0x7b, 0x74, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x20, 0x22, 0x73, 0x74, 0x79,
+	0x6c, 0x65, 0x22, 0x20, 0x2e, 0x7d, 0x7d, 0x0a,
+	0x3c, 0x2f, 0x68, 0x65, 0x61, 0x64, 0x3e, 0x0a,
+	0x0a, 0x3c, 0x62, 0x6f, 0x64, 0x79, 0x3e, 0x0a,
+	0x3c, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x3e,
 	0x7b, 0x7b, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61,
-	0x74, 0x65, 0x20, 0x22, 0x73, 0x74, 0x79, 0x6c,
-	0x65, 0x22, 0x20, 0x2e, 0x7d, 0x7d, 0x0a, 0x3c,
-	0x2f, 0x68, 0x65, 0x61, 0x64, 0x3e, 0x0a, 0x0a,
-	0x3c, 0x62, 0x6f, 0x64, 0x79, 0x3e, 0x0a, 0x3c,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x3e, 0x7b,
-	0x7b, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
-	0x65, 0x20, 0x22, 0x68, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x22, 0x20, 0x2e, 0x7d, 0x7d, 0x3c, 0x2f,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x3e, 0x0a,
-	0x3c, 0x64, 0x69, 0x76, 0x20, 0x69, 0x64, 0x3d,
-	0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x22, 0x3e, 0x7b, 0x7b, 0x74, 0x65, 0x6d, 0x70,
-	0x6c, 0x61, 0x74, 0x65, 0x20, 0x22, 0x63, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x20, 0x2e,
-	0x7d, 0x7d, 0x3c, 0x2f, 0x64, 0x69, 0x76, 0x3e,
-	0x0a, 0x3c, 0x66, 0x6f, 0x6f, 0x74, 0x65, 0x72,
-	0x3e, 0x7b, 0x7b, 0x74, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x20, 0x22, 0x66, 0x6f, 0x6f,
-	0x74, 0x65, 0x72, 0x22, 0x20, 0x2e, 0x7d, 0x7d,
-	0x3c, 0x2f, 0x66, 0x6f, 0x6f, 0x74, 0x65, 0x72,
-	0x3e, 0x0a, 0x7b, 0x7b, 0x74, 0x65, 0x6d, 0x70,
-	0x6c, 0x61, 0x74, 0x65, 0x20, 0x22, 0x6a, 0x61,
-	0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x22, 0x20, 0x2e, 0x7d, 0x7d, 0x0a, 0x3c, 0x2f,
-	0x62, 0x6f, 0x64, 0x79, 0x3e, 0x0a, 0x0a, 0x3c,
-	0x2f, 0x68, 0x74, 0x6d, 0x6c, 0x3e, 0x0a,
+	0x74, 0x65, 0x20, 0x22, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x22, 0x20, 0x2e, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x3e,
+	0x0a, 0x3c, 0x64, 0x69, 0x76, 0x20, 0x69, 0x64,
+	0x3d, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x3e, 0x7b, 0x7b, 0x74, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x20, 0x22, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x20,
+	0x2e, 0x7d, 0x7d, 0x3c, 0x2f, 0x64, 0x69, 0x76,
+	0x3e, 0x0a, 0x3c, 0x66, 0x6f, 0x6f, 0x74, 0x65,
+	0x72, 0x3e, 0x7b, 0x7b, 0x74, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x20, 0x22, 0x66, 0x6f,
+	0x6f, 0x74, 0x65, 0x72, 0x22, 0x20, 0x2e, 0x7d,
+	0x7d, 0x3c, 0x2f, 0x66, 0x6f, 0x6f, 0x74, 0x65,
+	0x72, 0x3e, 0x0a, 0x7b, 0x7b, 0x74, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x20, 0x22, 0x6a,
+	0x61, 0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x22, 0x20, 0x2e, 0x7d, 0x7d, 0x0a, 0x3c,
+	0x2f, 0x62, 0x6f, 0x64, 0x79, 0x3e, 0x0a, 0x0a,
+	0x3c, 0x2f, 0x68, 0x74, 0x6d, 0x6c, 0x3e, 0x0a,
+}
+
+var baseTemplatesArchiveGohtml = []byte{
+	0x7b, 0x7b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65,
+	0x20, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x7d, 0x7d, 0x0a, 0x3c, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x3c,
+	0x68, 0x31, 0x3e, 0x41, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x7b, 0x7b, 0x69, 0x66, 0x20, 0x2e,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x7d, 0x7d,
+	0x20, 0x6f, 0x66, 0x20, 0x7b, 0x7b, 0x2e, 0x50,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x7d, 0x7d, 0x7b,
+	0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x3c, 0x2f,
+	0x68, 0x31, 0x3e, 0x0a, 0x7b, 0x7b, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x20, 0x2e, 0x41, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x7d, 0x7d, 0x0a, 0x3c,
+	0x68, 0x32, 0x3e, 0x3c, 0x61, 0x20, 0x68, 0x72,
+	0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e, 0x55,
+	0x52, 0x4c, 0x7d, 0x7d, 0x22, 0x3e, 0x7b, 0x7b,
+	0x2e, 0x59, 0x65, 0x61, 0x72, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x61, 0x3e, 0x20, 0x28, 0x7b, 0x7b, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x7d, 0x7d, 0x29,
+	0x3c, 0x2f, 0x68, 0x32, 0x3e, 0x0a, 0x7b, 0x7b,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e, 0x4d,
+	0x6f, 0x6e, 0x74, 0x68, 0x73, 0x7d, 0x7d, 0x0a,
+	0x3c, 0x68, 0x33, 0x3e, 0x3c, 0x61, 0x20, 0x68,
+	0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e,
+	0x55, 0x52, 0x4c, 0x7d, 0x7d, 0x22, 0x3e, 0x7b,
+	0x7b, 0x2e, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x7d,
+	0x7d, 0x20, 0x7b, 0x7b, 0x2e, 0x59, 0x65, 0x61,
+	0x72, 0x7d, 0x7d, 0x3c, 0x2f, 0x61, 0x3e, 0x20,
+	0x28, 0x7b, 0x7b, 0x2e, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x7d, 0x7d, 0x29, 0x3c, 0x2f, 0x68, 0x33,
+	0x3e, 0x0a, 0x3c, 0x75, 0x6c, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e,
+	0x50, 0x6f, 0x73, 0x74, 0x73, 0x7d, 0x7d, 0x0a,
+	0x3c, 0x6c, 0x69, 0x3e, 0x3c, 0x61, 0x20, 0x68,
+	0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e,
+	0x50, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x6e,
+	0x6b, 0x7d, 0x7d, 0x22, 0x3e, 0x7b, 0x7b, 0x2e,
+	0x54, 0x69, 0x74, 0x6c, 0x65, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x61, 0x3e, 0x20, 0x3c, 0x73, 0x6d, 0x61,
+	0x6c, 0x6c, 0x3e, 0x7b, 0x7b, 0x2e, 0x44, 0x61,
+	0x74, 0x65, 0x2e, 0x57, 0x65, 0x65, 0x6b, 0x64,
+	0x61, 0x79, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2e,
+	0x44, 0x61, 0x74, 0x65, 0x2e, 0x44, 0x61, 0x79,
+	0x7d, 0x7d, 0x3c, 0x2f, 0x73, 0x6d, 0x61, 0x6c,
+	0x6c, 0x3e, 0x3c, 0x2f, 0x6c, 0x69, 0x3e, 0x0a,
+	0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a,
+	0x3c, 0x2f, 0x75, 0x6c, 0x3e, 0x0a, 0x7b, 0x7b,
+	0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x7b, 0x7b,
+	0x65, 0x6c, 0x73, 0x65, 0x7d, 0x7d, 0x0a, 0x3c,
+	0x70, 0x3e, 0x54, 0x68, 0x65, 0x72, 0x65, 0x20,
+	0x61, 0x72, 0x65, 0x20, 0x6e, 0x6f, 0x20, 0x70,
+	0x6f, 0x73, 0x74, 0x73, 0x20, 0x6f, 0x6e, 0x20,
+	0x74, 0x68, 0x69, 0x73, 0x20, 0x62, 0x6c, 0x6f,
+	0x67, 0x21, 0x3c, 0x2f, 0x70, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x7b,
+	0x7b, 0x69, 0x66, 0x20, 0x2e, 0x50, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x7d, 0x7d, 0x3c, 0x70, 0x3e,
+	0x53, 0x65, 0x65, 0x20, 0x74, 0x68, 0x65, 0x20,
+	0x77, 0x68, 0x6f, 0x6c, 0x65, 0x20, 0x3c, 0x61,
+	0x20, 0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x2f,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x2f,
+	0x22, 0x3e, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x3c, 0x2f, 0x61, 0x3e, 0x2e, 0x3c, 0x2f,
+	0x70, 0x3e, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d,
+	0x7d, 0x0a, 0x3c, 0x2f, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x7b, 0x7b, 0x65,
+	0x6e, 0x64, 0x7d, 0x7d, 0x0a,
 }
 
 var baseTemplatesFooterGohtml = []byte{
@@ -3655,15 +3851,34 @@ var baseTemplatesHeaderGohtml = []byte{
 	0x61, 0x6e, 0x20, 0x73, 0x74, 0x79, 0x6c, 0x65,
 	0x3d, 0x22, 0x70, 0x61, 0x67, 0x65, 0x2d, 0x6c,
 	0x69, 0x6e, 0x6b, 0x22, 0x3e, 0x3c, 0x61, 0x20,
-	0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x2f, 0x70,
-	0x61, 0x67, 0x65, 0x73, 0x2f, 0x7b, 0x7b, 0x2e,
-	0x47, 0x65, 0x74, 0x49, 0x44, 0x7d, 0x7d, 0x22,
-	0x3e, 0x7b, 0x7b, 0x2e, 0x54, 0x69, 0x74, 0x6c,
-	0x65, 0x7d, 0x7d, 0x3c, 0x2f, 0x61, 0x3e, 0x3c,
-	0x2f, 0x73, 0x70, 0x61, 0x6e, 0x3e, 0x0a, 0x7b,
-	0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x3c,
-	0x62, 0x72, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e,
-	0x64, 0x7d, 0x7d, 0x0a,
+	0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b,
+	0x2e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x69,
+	0x6e, 0x6b, 0x7d, 0x7d, 0x22, 0x3e, 0x7b, 0x7b,
+	0x2e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x7d, 0x7d,
+	0x3c, 0x2f, 0x61, 0x3e, 0x3c, 0x2f, 0x73, 0x70,
+	0x61, 0x6e, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e,
+	0x64, 0x7d, 0x7d, 0x0a, 0x3c, 0x73, 0x70, 0x61,
+	0x6e, 0x20, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x3d,
+	0x22, 0x70, 0x61, 0x67, 0x65, 0x2d, 0x6c, 0x69,
+	0x6e, 0x6b, 0x22, 0x3e, 0x3c, 0x61, 0x20, 0x68,
+	0x72, 0x65, 0x66, 0x3d, 0x22, 0x2f, 0x61, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x2f, 0x22, 0x3e,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x3c,
+	0x2f, 0x61, 0x3e, 0x3c, 0x2f, 0x73, 0x70, 0x61,
+	0x6e, 0x3e, 0x0a, 0x3c, 0x66, 0x6f, 0x72, 0x6d,
+	0x20, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x3d,
+	0x22, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x22, 0x20, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x3d, 0x22, 0x67, 0x65, 0x74, 0x22, 0x3e, 0x3c,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x20, 0x74, 0x79,
+	0x70, 0x65, 0x3d, 0x22, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x22, 0x20, 0x6e, 0x61, 0x6d, 0x65,
+	0x3d, 0x22, 0x71, 0x22, 0x20, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x3d, 0x22, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x22, 0x3e, 0x3c, 0x2f, 0x66, 0x6f, 0x72, 0x6d,
+	0x3e, 0x0a, 0x3c, 0x62, 0x72, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a,
 }
 
 var baseTemplatesIndexGohtml = []byte{
@@ -3674,40 +3889,112 @@ var baseTemplatesIndexGohtml = []byte{
 	0x7b, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e,
 	0x50, 0x6f, 0x73, 0x74, 0x73, 0x7d, 0x7d, 0x0a,
 	0x3c, 0x68, 0x32, 0x3e, 0x3c, 0x61, 0x20, 0x68,
-	0x72, 0x65, 0x66, 0x3d, 0x22, 0x2f, 0x70, 0x6f,
-	0x73, 0x74, 0x73, 0x2f, 0x7b, 0x7b, 0x2e, 0x47,
-	0x65, 0x74, 0x49, 0x44, 0x7d, 0x7d, 0x22, 0x3e,
-	0x7b, 0x7b, 0x2e, 0x54, 0x69, 0x74, 0x6c, 0x65,
-	0x7d, 0x7d, 0x3c, 0x2f, 0x61, 0x3e, 0x3c, 0x2f,
-	0x68, 0x32, 0x3e, 0x0a, 0x3c, 0x70, 0x3e, 0x3c,
-	0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x42, 0x79,
-	0x20, 0x7b, 0x7b, 0x2e, 0x41, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x7d, 0x7d, 0x2c, 0x20, 0x7b, 0x7b,
-	0x2e, 0x44, 0x61, 0x74, 0x65, 0x2e, 0x57, 0x65,
-	0x65, 0x6b, 0x64, 0x61, 0x79, 0x7d, 0x7d, 0x20,
-	0x7b, 0x7b, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x2e,
-	0x44, 0x61, 0x79, 0x7d, 0x7d, 0x20, 0x7b, 0x7b,
-	0x2e, 0x44, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x6f,
-	0x6e, 0x74, 0x68, 0x7d, 0x7d, 0x20, 0x7b, 0x7b,
-	0x2e, 0x44, 0x61, 0x74, 0x65, 0x2e, 0x59, 0x65,
-	0x61, 0x72, 0x7d, 0x7d, 0x3c, 0x2f, 0x73, 0x6d,
-	0x61, 0x6c, 0x6c, 0x3e, 0x3c, 0x2f, 0x70, 0x3e,
-	0x0a, 0x3c, 0x70, 0x3e, 0x3c, 0x73, 0x6d, 0x61,
-	0x6c, 0x6c, 0x3e, 0x7b, 0x7b, 0x2e, 0x41, 0x62,
-	0x73, 0x74, 0x72, 0x61, 0x63, 0x74, 0x7d, 0x7d,
-	0x3c, 0x2f, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e,
-	0x3c, 0x2f, 0x70, 0x3e, 0x0a, 0x7b, 0x7b, 0x65,
-	0x6c, 0x73, 0x65, 0x7d, 0x7d, 0x3c, 0x64, 0x69,
-	0x76, 0x3e, 0x3c, 0x68, 0x32, 0x3e, 0x54, 0x68,
-	0x65, 0x72, 0x65, 0x20, 0x61, 0x72, 0x65, 0x20,
-	0x6e, 0x6f, 0x74, 0x20, 0x70, 0x6f, 0x73, 0x74,
-	0x20, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x69, 0x73,
-	0x20, 0x62, 0x6c, 0x6f, 0x67, 0x21, 0x3c, 0x2f,
-	0x68, 0x32, 0x3e, 0x3c, 0x2f, 0x64, 0x69, 0x76,
-	0x3e, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d,
-	0x0a, 0x3c, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63,
-	0x6c, 0x65, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e,
-	0x64, 0x7d, 0x7d, 0x0a,
+	0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e,
+	0x50, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x6e,
+	0x6b, 0x7d, 0x7d, 0x22, 0x3e, 0x7b, 0x7b, 0x2e,
+	0x54, 0x69, 0x74, 0x6c, 0x65, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x61, 0x3e, 0x7b, 0x7b, 0x69, 0x66, 0x20,
+	0x2e, 0x49, 0x73, 0x44, 0x72, 0x61, 0x66, 0x74,
+	0x7d, 0x7d, 0x20, 0x3c, 0x73, 0x6d, 0x61, 0x6c,
+	0x6c, 0x20, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x3d,
+	0x22, 0x64, 0x72, 0x61, 0x66, 0x74, 0x22, 0x3e,
+	0x64, 0x72, 0x61, 0x66, 0x74, 0x3c, 0x2f, 0x73,
+	0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x7b, 0x7b, 0x65,
+	0x6e, 0x64, 0x7d, 0x7d, 0x3c, 0x2f, 0x68, 0x32,
+	0x3e, 0x0a, 0x3c, 0x70, 0x3e, 0x3c, 0x73, 0x6d,
+	0x61, 0x6c, 0x6c, 0x3e, 0x42, 0x79, 0x20, 0x7b,
+	0x7b, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x7d, 0x7d, 0x2c, 0x20, 0x7b, 0x7b, 0x2e, 0x44,
+	0x61, 0x74, 0x65, 0x2e, 0x57, 0x65, 0x65, 0x6b,
+	0x64, 0x61, 0x79, 0x7d, 0x7d, 0x20, 0x7b, 0x7b,
+	0x2e, 0x44, 0x61, 0x74, 0x65, 0x2e, 0x44, 0x61,
+	0x79, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2e, 0x44,
+	0x61, 0x74, 0x65, 0x2e, 0x4d, 0x6f, 0x6e, 0x74,
+	0x68, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2e, 0x44,
+	0x61, 0x74, 0x65, 0x2e, 0x59, 0x65, 0x61, 0x72,
+	0x7d, 0x7d, 0x7b, 0x7b, 0x69, 0x66, 0x20, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x54,
+	0x69, 0x6d, 0x65, 0x7d, 0x7d, 0x2c, 0x20, 0x7b,
+	0x7b, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x54, 0x69, 0x6d, 0x65, 0x7d, 0x7d, 0x20,
+	0x6d, 0x69, 0x6e, 0x20, 0x72, 0x65, 0x61, 0x64,
+	0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x3c,
+	0x2f, 0x70, 0x3e, 0x0a, 0x3c, 0x70, 0x3e, 0x3c,
+	0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x7b, 0x7b,
+	0x2e, 0x45, 0x78, 0x63, 0x65, 0x72, 0x70, 0x74,
+	0x7d, 0x7d, 0x3c, 0x2f, 0x73, 0x6d, 0x61, 0x6c,
+	0x6c, 0x3e, 0x3c, 0x2f, 0x70, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x69, 0x66, 0x20, 0x2e, 0x54, 0x61, 0x67,
+	0x73, 0x7d, 0x7d, 0x3c, 0x70, 0x3e, 0x3c, 0x73,
+	0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x54, 0x61, 0x67,
+	0x73, 0x3a, 0x20, 0x7b, 0x7b, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x20, 0x2e, 0x54, 0x61, 0x67, 0x73,
+	0x7d, 0x7d, 0x3c, 0x61, 0x20, 0x68, 0x72, 0x65,
+	0x66, 0x3d, 0x22, 0x2f, 0x74, 0x61, 0x67, 0x73,
+	0x2f, 0x7b, 0x7b, 0x75, 0x72, 0x6c, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x20, 0x2e, 0x7d, 0x7d, 0x22,
+	0x3e, 0x7b, 0x7b, 0x2e, 0x7d, 0x7d, 0x3c, 0x2f,
+	0x61, 0x3e, 0x20, 0x7b, 0x7b, 0x65, 0x6e, 0x64,
+	0x7d, 0x7d, 0x3c, 0x2f, 0x73, 0x6d, 0x61, 0x6c,
+	0x6c, 0x3e, 0x3c, 0x2f, 0x70, 0x3e, 0x7b, 0x7b,
+	0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x7b, 0x7b,
+	0x65, 0x6c, 0x73, 0x65, 0x7d, 0x7d, 0x3c, 0x64,
+	0x69, 0x76, 0x3e, 0x3c, 0x68, 0x32, 0x3e, 0x54,
+	0x68, 0x65, 0x72, 0x65, 0x20, 0x61, 0x72, 0x65,
+	0x20, 0x6e, 0x6f, 0x74, 0x20, 0x70, 0x6f, 0x73,
+	0x74, 0x20, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x69,
+	0x73, 0x20, 0x62, 0x6c, 0x6f, 0x67, 0x21, 0x3c,
+	0x2f, 0x68, 0x32, 0x3e, 0x3c, 0x2f, 0x64, 0x69,
+	0x76, 0x3e, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d,
+	0x7d, 0x0a, 0x3c, 0x2f, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x7b, 0x7b, 0x77,
+	0x69, 0x74, 0x68, 0x20, 0x2e, 0x50, 0x61, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x7d,
+	0x7d, 0x7b, 0x7b, 0x69, 0x66, 0x20, 0x67, 0x74,
+	0x20, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x50,
+	0x61, 0x67, 0x65, 0x73, 0x20, 0x31, 0x7d, 0x7d,
+	0x0a, 0x3c, 0x6e, 0x61, 0x76, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x69, 0x66, 0x20, 0x2e, 0x50, 0x72, 0x65,
+	0x76, 0x55, 0x52, 0x4c, 0x7d, 0x7d, 0x3c, 0x61,
+	0x20, 0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b,
+	0x7b, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x55, 0x52,
+	0x4c, 0x7d, 0x7d, 0x22, 0x3e, 0x26, 0x6c, 0x61,
+	0x72, 0x72, 0x3b, 0x20, 0x4e, 0x65, 0x77, 0x65,
+	0x72, 0x20, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x3c,
+	0x2f, 0x61, 0x3e, 0x7b, 0x7b, 0x65, 0x6e, 0x64,
+	0x7d, 0x7d, 0x0a, 0x3c, 0x73, 0x6d, 0x61, 0x6c,
+	0x6c, 0x3e, 0x50, 0x61, 0x67, 0x65, 0x20, 0x7b,
+	0x7b, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x7d, 0x7d,
+	0x20, 0x6f, 0x66, 0x20, 0x7b, 0x7b, 0x2e, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65,
+	0x73, 0x7d, 0x7d, 0x3c, 0x2f, 0x73, 0x6d, 0x61,
+	0x6c, 0x6c, 0x3e, 0x0a, 0x7b, 0x7b, 0x69, 0x66,
+	0x20, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x52,
+	0x4c, 0x7d, 0x7d, 0x3c, 0x61, 0x20, 0x68, 0x72,
+	0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e, 0x4e,
+	0x65, 0x78, 0x74, 0x55, 0x52, 0x4c, 0x7d, 0x7d,
+	0x22, 0x3e, 0x4f, 0x6c, 0x64, 0x65, 0x72, 0x20,
+	0x70, 0x6f, 0x73, 0x74, 0x73, 0x20, 0x26, 0x72,
+	0x61, 0x72, 0x72, 0x3b, 0x3c, 0x2f, 0x61, 0x3e,
+	0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a,
+	0x3c, 0x2f, 0x6e, 0x61, 0x76, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x7b, 0x7b,
+	0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x3c, 0x70,
+	0x3e, 0x3c, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e,
+	0x42, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x20, 0x62,
+	0x79, 0x20, 0x3c, 0x61, 0x20, 0x68, 0x72, 0x65,
+	0x66, 0x3d, 0x22, 0x2f, 0x74, 0x61, 0x67, 0x73,
+	0x2f, 0x22, 0x3e, 0x74, 0x61, 0x67, 0x73, 0x3c,
+	0x2f, 0x61, 0x3e, 0x20, 0x6f, 0x72, 0x20, 0x3c,
+	0x61, 0x20, 0x68, 0x72, 0x65, 0x66, 0x3d, 0x22,
+	0x2f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x2f, 0x22, 0x3e, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x3c, 0x2f, 0x61, 0x3e, 0x2e, 0x3c, 0x2f, 0x73,
+	0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x3c, 0x2f, 0x70,
+	0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d,
+	0x7d, 0x0a,
 }
 
 var baseTemplatesJavascriptGohtml = []byte{
@@ -3722,34 +4009,40 @@ var baseTemplatesJavascriptGohtml = []byte{
 	0x65, 0x3d, 0x22, 0x74, 0x65, 0x78, 0x74, 0x2f,
 	0x6a, 0x61, 0x76, 0x61, 0x73, 0x63, 0x72, 0x69,
 	0x70, 0x74, 0x22, 0x20, 0x73, 0x72, 0x63, 0x3d,
-	0x22, 0x2f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73,
-	0x2f, 0x6a, 0x73, 0x2f, 0x62, 0x72, 0x6f, 0x67,
-	0x2e, 0x6a, 0x73, 0x22, 0x3e, 0x3c, 0x2f, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x3e, 0x0a, 0x3c,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x20, 0x74,
-	0x79, 0x70, 0x65, 0x3d, 0x22, 0x74, 0x65, 0x78,
-	0x74, 0x2f, 0x6a, 0x61, 0x76, 0x61, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x22, 0x20, 0x73, 0x72,
-	0x63, 0x3d, 0x22, 0x2f, 0x61, 0x73, 0x73, 0x65,
-	0x74, 0x73, 0x2f, 0x6a, 0x73, 0x2f, 0x68, 0x69,
-	0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e,
-	0x6d, 0x69, 0x6e, 0x2e, 0x6a, 0x73, 0x22, 0x3e,
-	0x3c, 0x2f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x3e, 0x0a, 0x0a, 0x3c, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x20, 0x74, 0x79, 0x70, 0x65, 0x3d,
-	0x22, 0x74, 0x65, 0x78, 0x74, 0x2f, 0x6a, 0x61,
-	0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x22, 0x3e, 0x0a, 0x2f, 0x2f, 0x20, 0x50, 0x6c,
-	0x65, 0x61, 0x73, 0x65, 0x20, 0x64, 0x6f, 0x20,
-	0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68,
-	0x74, 0x20, 0x6d, 0x79, 0x20, 0x63, 0x6f, 0x64,
-	0x65, 0x2e, 0x0a, 0x68, 0x6c, 0x6a, 0x73, 0x2e,
-	0x69, 0x6e, 0x69, 0x74, 0x48, 0x69, 0x67, 0x68,
-	0x6c, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67,
-	0x4f, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x28, 0x29,
-	0x3b, 0x0a, 0x3c, 0x2f, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e,
-	0x64, 0x7d, 0x7d, 0x0a,
+	0x22, 0x7b, 0x7b, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x20, 0x22, 0x6a, 0x73, 0x2f, 0x62, 0x72, 0x6f,
+	0x67, 0x2e, 0x6a, 0x73, 0x22, 0x7d, 0x7d, 0x22,
+	0x3e, 0x3c, 0x2f, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x3e, 0x0a, 0x7b, 0x7b, 0x69, 0x66, 0x20,
+	0x6e, 0x6f, 0x74, 0x20, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x48, 0x69, 0x67, 0x68, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x7d,
+	0x7d, 0x0a, 0x3c, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x20, 0x74, 0x79, 0x70, 0x65, 0x3d, 0x22,
+	0x74, 0x65, 0x78, 0x74, 0x2f, 0x6a, 0x61, 0x76,
+	0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x22,
+	0x20, 0x73, 0x72, 0x63, 0x3d, 0x22, 0x7b, 0x7b,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x20, 0x22, 0x6a,
+	0x73, 0x2f, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x2e, 0x6d, 0x69, 0x6e, 0x2e,
+	0x6a, 0x73, 0x22, 0x7d, 0x7d, 0x22, 0x3e, 0x3c,
+	0x2f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x3e,
+	0x0a, 0x0a, 0x3c, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x20, 0x74, 0x79, 0x70, 0x65, 0x3d, 0x22,
+	0x74, 0x65, 0x78, 0x74, 0x2f, 0x6a, 0x61, 0x76,
+	0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x22,
+	0x3e, 0x0a, 0x2f, 0x2f, 0x20, 0x50, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x20, 0x64, 0x6f, 0x20, 0x68,
+	0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74,
+	0x20, 0x6d, 0x79, 0x20, 0x63, 0x6f, 0x64, 0x65,
+	0x2e, 0x0a, 0x68, 0x6c, 0x6a, 0x73, 0x2e, 0x69,
+	0x6e, 0x69, 0x74, 0x48, 0x69, 0x67, 0x68, 0x6c,
+	0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x4f,
+	0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x28, 0x29, 0x3b,
+	0x0a, 0x3c, 0x2f, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64,
+	0x7d, 0x7d, 0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64,
+	0x7d, 0x7d, 0x0a,
 }
 
 var baseTemplatesLangselectGohtml = []byte{
@@ -3784,6 +4077,16 @@ var baseTemplatesPostGohtml = []byte{
 	0x2f, 0x70, 0x3e, 0x0a, 0x0a, 0x3c, 0x68, 0x31,
 	0x3e, 0x7b, 0x7b, 0x2e, 0x54, 0x69, 0x74, 0x6c,
 	0x65, 0x7d, 0x7d, 0x3c, 0x2f, 0x68, 0x31, 0x3e,
+	0x0a, 0x7b, 0x7b, 0x69, 0x66, 0x20, 0x2e, 0x49,
+	0x73, 0x44, 0x72, 0x61, 0x66, 0x74, 0x7d, 0x7d,
+	0x3c, 0x70, 0x20, 0x63, 0x6c, 0x61, 0x73, 0x73,
+	0x3d, 0x22, 0x64, 0x72, 0x61, 0x66, 0x74, 0x22,
+	0x3e, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73,
+	0x20, 0x61, 0x20, 0x64, 0x72, 0x61, 0x66, 0x74,
+	0x2c, 0x20, 0x69, 0x74, 0x20, 0x69, 0x73, 0x6e,
+	0x27, 0x74, 0x20, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x2e, 0x3c, 0x2f, 0x70,
+	0x3e, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d,
 	0x0a, 0x3c, 0x70, 0x3e, 0x0a, 0x20, 0x20, 0x20,
 	0x20, 0x3c, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e,
 	0x42, 0x79, 0x20, 0x7b, 0x7b, 0x2e, 0x41, 0x75,
@@ -3797,14 +4100,271 @@ var baseTemplatesPostGohtml = []byte{
 	0x20, 0x7b, 0x7b, 0x2e, 0x44, 0x61, 0x74, 0x65,
 	0x2e, 0x59, 0x65, 0x61, 0x72, 0x7d, 0x7d, 0x3c,
 	0x2f, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x0a,
-	0x3c, 0x2f, 0x70, 0x3e, 0x0a, 0x0a, 0x3c, 0x61,
-	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a,
-	0x20, 0x20, 0x20, 0x20, 0x7b, 0x7b, 0x2e, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x7d, 0x7d,
-	0x0a, 0x3c, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63,
-	0x6c, 0x65, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e,
-	0x64, 0x7d, 0x7d, 0x0a, 0x7b, 0x7b, 0x65, 0x6e,
-	0x64, 0x7d, 0x7d, 0x0a,
+	0x3c, 0x2f, 0x70, 0x3e, 0x0a, 0x7b, 0x7b, 0x69,
+	0x66, 0x20, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x7d, 0x7d, 0x3c,
+	0x70, 0x3e, 0x3c, 0x73, 0x6d, 0x61, 0x6c, 0x6c,
+	0x3e, 0x46, 0x69, 0x6c, 0x65, 0x64, 0x20, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x20, 0x7b, 0x7b, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e, 0x43, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x7d, 0x7d, 0x3c, 0x61, 0x20, 0x68, 0x72, 0x65,
+	0x66, 0x3d, 0x22, 0x2f, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x2f, 0x7b,
+	0x7b, 0x75, 0x72, 0x6c, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x20, 0x2e, 0x7d, 0x7d, 0x22, 0x3e, 0x7b,
+	0x7b, 0x2e, 0x7d, 0x7d, 0x3c, 0x2f, 0x61, 0x3e,
+	0x20, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d,
+	0x3c, 0x2f, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e,
+	0x3c, 0x2f, 0x70, 0x3e, 0x7b, 0x7b, 0x65, 0x6e,
+	0x64, 0x7d, 0x7d, 0x0a, 0x7b, 0x7b, 0x69, 0x66,
+	0x20, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x7d, 0x7d,
+	0x3c, 0x70, 0x3e, 0x3c, 0x73, 0x6d, 0x61, 0x6c,
+	0x6c, 0x3e, 0x54, 0x61, 0x67, 0x73, 0x3a, 0x20,
+	0x7b, 0x7b, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x20,
+	0x2e, 0x54, 0x61, 0x67, 0x73, 0x7d, 0x7d, 0x3c,
+	0x61, 0x20, 0x68, 0x72, 0x65, 0x66, 0x3d, 0x22,
+	0x2f, 0x74, 0x61, 0x67, 0x73, 0x2f, 0x7b, 0x7b,
+	0x75, 0x72, 0x6c, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x20, 0x2e, 0x7d, 0x7d, 0x22, 0x3e, 0x7b, 0x7b,
+	0x2e, 0x7d, 0x7d, 0x3c, 0x2f, 0x61, 0x3e, 0x20,
+	0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x3c,
+	0x2f, 0x70, 0x3e, 0x7b, 0x7b, 0x65, 0x6e, 0x64,
+	0x7d, 0x7d, 0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64,
+	0x7d, 0x7d, 0x0a, 0x7b, 0x7b, 0x77, 0x69, 0x74,
+	0x68, 0x20, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x7d, 0x7d, 0x0a, 0x3c, 0x6e, 0x61, 0x76,
+	0x20, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x3d, 0x22,
+	0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x3e,
+	0x0a, 0x3c, 0x70, 0x3e, 0x54, 0x68, 0x69, 0x73,
+	0x20, 0x70, 0x6f, 0x73, 0x74, 0x20, 0x69, 0x73,
+	0x20, 0x70, 0x61, 0x72, 0x74, 0x20, 0x6f, 0x66,
+	0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x20, 0x3c, 0x61, 0x20, 0x68,
+	0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e,
+	0x55, 0x52, 0x4c, 0x7d, 0x7d, 0x22, 0x3e, 0x7b,
+	0x7b, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x7d, 0x7d,
+	0x3c, 0x2f, 0x61, 0x3e, 0x3a, 0x3c, 0x2f, 0x70,
+	0x3e, 0x0a, 0x3c, 0x6f, 0x6c, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e,
+	0x50, 0x6f, 0x73, 0x74, 0x73, 0x7d, 0x7d, 0x0a,
+	0x3c, 0x6c, 0x69, 0x3e, 0x7b, 0x7b, 0x69, 0x66,
+	0x20, 0x65, 0x71, 0x20, 0x2e, 0x47, 0x65, 0x74,
+	0x49, 0x44, 0x20, 0x24, 0x2e, 0x43, 0x75, 0x72,
+	0x50, 0x6f, 0x73, 0x74, 0x2e, 0x47, 0x65, 0x74,
+	0x49, 0x44, 0x7d, 0x7d, 0x7b, 0x7b, 0x2e, 0x54,
+	0x69, 0x74, 0x6c, 0x65, 0x7d, 0x7d, 0x7b, 0x7b,
+	0x65, 0x6c, 0x73, 0x65, 0x7d, 0x7d, 0x3c, 0x61,
+	0x20, 0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b,
+	0x7b, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6c,
+	0x69, 0x6e, 0x6b, 0x7d, 0x7d, 0x22, 0x3e, 0x7b,
+	0x7b, 0x2e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x7d,
+	0x7d, 0x3c, 0x2f, 0x61, 0x3e, 0x7b, 0x7b, 0x65,
+	0x6e, 0x64, 0x7d, 0x7d, 0x3c, 0x2f, 0x6c, 0x69,
+	0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d,
+	0x7d, 0x0a, 0x3c, 0x2f, 0x6f, 0x6c, 0x3e, 0x0a,
+	0x3c, 0x2f, 0x6e, 0x61, 0x76, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x7b,
+	0x7b, 0x77, 0x69, 0x74, 0x68, 0x20, 0x2e, 0x43,
+	0x75, 0x72, 0x50, 0x6f, 0x73, 0x74, 0x7d, 0x7d,
+	0x0a, 0x7b, 0x7b, 0x2e, 0x54, 0x4f, 0x43, 0x7d,
+	0x7d, 0x0a, 0x0a, 0x3c, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x20, 0x20, 0x20,
+	0x20, 0x7b, 0x7b, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x7d, 0x7d, 0x0a, 0x3c, 0x2f,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x3e,
+	0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d,
+	0x0a, 0x7b, 0x7b, 0x69, 0x66, 0x20, 0x6f, 0x72,
+	0x20, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x49, 0x6e,
+	0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x20, 0x2e,
+	0x4e, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x53, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x7d, 0x7d, 0x0a, 0x3c,
+	0x6e, 0x61, 0x76, 0x20, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x3d, 0x22, 0x73, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x22, 0x3e, 0x0a, 0x7b, 0x7b, 0x77, 0x69,
+	0x74, 0x68, 0x20, 0x2e, 0x50, 0x72, 0x65, 0x76,
+	0x49, 0x6e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x7d, 0x7d, 0x3c, 0x70, 0x3e, 0x50, 0x72, 0x65,
+	0x76, 0x69, 0x6f, 0x75, 0x73, 0x20, 0x70, 0x61,
+	0x72, 0x74, 0x3a, 0x20, 0x3c, 0x61, 0x20, 0x68,
+	0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e,
+	0x50, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x6e,
+	0x6b, 0x7d, 0x7d, 0x22, 0x3e, 0x7b, 0x7b, 0x2e,
+	0x54, 0x69, 0x74, 0x6c, 0x65, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x61, 0x3e, 0x3c, 0x2f, 0x70, 0x3e, 0x7b,
+	0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x7b,
+	0x7b, 0x77, 0x69, 0x74, 0x68, 0x20, 0x2e, 0x4e,
+	0x65, 0x78, 0x74, 0x49, 0x6e, 0x53, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x7d, 0x7d, 0x3c, 0x70, 0x3e,
+	0x4e, 0x65, 0x78, 0x74, 0x20, 0x70, 0x61, 0x72,
+	0x74, 0x3a, 0x20, 0x3c, 0x61, 0x20, 0x68, 0x72,
+	0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e, 0x50,
+	0x65, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x6e, 0x6b,
+	0x7d, 0x7d, 0x22, 0x3e, 0x7b, 0x7b, 0x2e, 0x54,
+	0x69, 0x74, 0x6c, 0x65, 0x7d, 0x7d, 0x3c, 0x2f,
+	0x61, 0x3e, 0x3c, 0x2f, 0x70, 0x3e, 0x7b, 0x7b,
+	0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x3c, 0x2f,
+	0x6e, 0x61, 0x76, 0x3e, 0x0a, 0x7b, 0x7b, 0x65,
+	0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x7b, 0x7b, 0x69,
+	0x66, 0x20, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74,
+	0x65, 0x64, 0x7d, 0x7d, 0x0a, 0x3c, 0x61, 0x73,
+	0x69, 0x64, 0x65, 0x20, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x3d, 0x22, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x65, 0x64, 0x22, 0x3e, 0x0a, 0x3c, 0x68, 0x34,
+	0x3e, 0x59, 0x6f, 0x75, 0x20, 0x6d, 0x69, 0x67,
+	0x68, 0x74, 0x20, 0x61, 0x6c, 0x73, 0x6f, 0x20,
+	0x6c, 0x69, 0x6b, 0x65, 0x3c, 0x2f, 0x68, 0x34,
+	0x3e, 0x0a, 0x3c, 0x75, 0x6c, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x7d,
+	0x7d, 0x3c, 0x6c, 0x69, 0x3e, 0x3c, 0x61, 0x20,
+	0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b,
+	0x2e, 0x50, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x69,
+	0x6e, 0x6b, 0x7d, 0x7d, 0x22, 0x3e, 0x7b, 0x7b,
+	0x2e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x7d, 0x7d,
+	0x3c, 0x2f, 0x61, 0x3e, 0x3c, 0x2f, 0x6c, 0x69,
+	0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d,
+	0x7d, 0x0a, 0x3c, 0x2f, 0x75, 0x6c, 0x3e, 0x0a,
+	0x3c, 0x2f, 0x61, 0x73, 0x69, 0x64, 0x65, 0x3e,
+	0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d,
+	0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d,
+	0x0a,
+}
+
+var baseTemplatesSearchGohtml = []byte{
+	0x7b, 0x7b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65,
+	0x20, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x7d, 0x7d, 0x0a, 0x3c, 0x66, 0x6f,
+	0x72, 0x6d, 0x20, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x3d, 0x22, 0x2f, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x22, 0x20, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x3d, 0x22, 0x67, 0x65, 0x74, 0x22,
+	0x3e, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x3c, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x20, 0x74, 0x79, 0x70,
+	0x65, 0x3d, 0x22, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x22, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x3d,
+	0x22, 0x71, 0x22, 0x20, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3d, 0x22, 0x7b, 0x7b, 0x68, 0x74, 0x6d,
+	0x6c, 0x20, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x7d, 0x7d, 0x22, 0x3e, 0x0a, 0x20, 0x20, 0x20,
+	0x20, 0x3c, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x20,
+	0x74, 0x79, 0x70, 0x65, 0x3d, 0x22, 0x73, 0x75,
+	0x62, 0x6d, 0x69, 0x74, 0x22, 0x20, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3d, 0x22, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x22, 0x3e, 0x0a, 0x3c, 0x2f,
+	0x66, 0x6f, 0x72, 0x6d, 0x3e, 0x0a, 0x7b, 0x7b,
+	0x69, 0x66, 0x20, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x7d, 0x7d, 0x0a, 0x3c, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x7b, 0x7b,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x7d, 0x7d,
+	0x0a, 0x3c, 0x68, 0x32, 0x3e, 0x3c, 0x61, 0x20,
+	0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b, 0x7b,
+	0x2e, 0x50, 0x6f, 0x73, 0x74, 0x2e, 0x50, 0x65,
+	0x72, 0x6d, 0x61, 0x6c, 0x69, 0x6e, 0x6b, 0x7d,
+	0x7d, 0x22, 0x3e, 0x7b, 0x7b, 0x2e, 0x50, 0x6f,
+	0x73, 0x74, 0x2e, 0x54, 0x69, 0x74, 0x6c, 0x65,
+	0x7d, 0x7d, 0x3c, 0x2f, 0x61, 0x3e, 0x3c, 0x2f,
+	0x68, 0x32, 0x3e, 0x0a, 0x3c, 0x70, 0x3e, 0x3c,
+	0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x42, 0x79,
+	0x20, 0x7b, 0x7b, 0x2e, 0x50, 0x6f, 0x73, 0x74,
+	0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x7d,
+	0x7d, 0x2c, 0x20, 0x7b, 0x7b, 0x2e, 0x50, 0x6f,
+	0x73, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x2e,
+	0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x7d,
+	0x7d, 0x20, 0x7b, 0x7b, 0x2e, 0x50, 0x6f, 0x73,
+	0x74, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x2e, 0x44,
+	0x61, 0x79, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2e,
+	0x50, 0x6f, 0x73, 0x74, 0x2e, 0x44, 0x61, 0x74,
+	0x65, 0x2e, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x7d,
+	0x7d, 0x20, 0x7b, 0x7b, 0x2e, 0x50, 0x6f, 0x73,
+	0x74, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x2e, 0x59,
+	0x65, 0x61, 0x72, 0x7d, 0x7d, 0x3c, 0x2f, 0x73,
+	0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x3c, 0x2f, 0x70,
+	0x3e, 0x0a, 0x3c, 0x70, 0x3e, 0x7b, 0x7b, 0x2e,
+	0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x7d,
+	0x7d, 0x3c, 0x2f, 0x70, 0x3e, 0x0a, 0x7b, 0x7b,
+	0x65, 0x6c, 0x73, 0x65, 0x7d, 0x7d, 0x3c, 0x64,
+	0x69, 0x76, 0x3e, 0x3c, 0x68, 0x32, 0x3e, 0x4e,
+	0x6f, 0x20, 0x70, 0x6f, 0x73, 0x74, 0x20, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x20, 0x22,
+	0x7b, 0x7b, 0x68, 0x74, 0x6d, 0x6c, 0x20, 0x2e,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x7d, 0x7d, 0x22,
+	0x2e, 0x3c, 0x2f, 0x68, 0x32, 0x3e, 0x3c, 0x2f,
+	0x64, 0x69, 0x76, 0x3e, 0x7b, 0x7b, 0x65, 0x6e,
+	0x64, 0x7d, 0x7d, 0x0a, 0x3c, 0x2f, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x7b,
+	0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a,
+}
+
+var baseTemplatesSeriesGohtml = []byte{
+	0x7b, 0x7b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65,
+	0x20, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x7d, 0x7d, 0x0a, 0x3c, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x77, 0x69, 0x74, 0x68, 0x20, 0x2e, 0x53,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x7d, 0x7d, 0x0a,
+	0x3c, 0x68, 0x31, 0x3e, 0x7b, 0x7b, 0x2e, 0x4e,
+	0x61, 0x6d, 0x65, 0x7d, 0x7d, 0x3c, 0x2f, 0x68,
+	0x31, 0x3e, 0x0a, 0x3c, 0x70, 0x3e, 0x41, 0x20,
+	0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x20, 0x69,
+	0x6e, 0x20, 0x7b, 0x7b, 0x2e, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x7d, 0x7d, 0x20, 0x70, 0x61, 0x72,
+	0x74, 0x73, 0x2e, 0x3c, 0x2f, 0x70, 0x3e, 0x0a,
+	0x3c, 0x6f, 0x6c, 0x3e, 0x0a, 0x7b, 0x7b, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e, 0x50, 0x6f,
+	0x73, 0x74, 0x73, 0x7d, 0x7d, 0x0a, 0x3c, 0x6c,
+	0x69, 0x3e, 0x3c, 0x61, 0x20, 0x68, 0x72, 0x65,
+	0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e, 0x50, 0x65,
+	0x72, 0x6d, 0x61, 0x6c, 0x69, 0x6e, 0x6b, 0x7d,
+	0x7d, 0x22, 0x3e, 0x7b, 0x7b, 0x2e, 0x54, 0x69,
+	0x74, 0x6c, 0x65, 0x7d, 0x7d, 0x3c, 0x2f, 0x61,
+	0x3e, 0x20, 0x3c, 0x73, 0x6d, 0x61, 0x6c, 0x6c,
+	0x3e, 0x7b, 0x7b, 0x2e, 0x44, 0x61, 0x74, 0x65,
+	0x2e, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79,
+	0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2e, 0x44, 0x61,
+	0x74, 0x65, 0x2e, 0x44, 0x61, 0x79, 0x7d, 0x7d,
+	0x20, 0x7b, 0x7b, 0x2e, 0x44, 0x61, 0x74, 0x65,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x7d, 0x7d,
+	0x20, 0x7b, 0x7b, 0x2e, 0x44, 0x61, 0x74, 0x65,
+	0x2e, 0x59, 0x65, 0x61, 0x72, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x3c,
+	0x2f, 0x6c, 0x69, 0x3e, 0x0a, 0x7b, 0x7b, 0x65,
+	0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x3c, 0x2f, 0x6f,
+	0x6c, 0x3e, 0x0a, 0x3c, 0x70, 0x3e, 0x53, 0x65,
+	0x65, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x74, 0x68,
+	0x65, 0x20, 0x3c, 0x61, 0x20, 0x68, 0x72, 0x65,
+	0x66, 0x3d, 0x22, 0x2f, 0x73, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x2f, 0x22, 0x3e, 0x73, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x3c, 0x2f, 0x61, 0x3e, 0x2e,
+	0x3c, 0x2f, 0x70, 0x3e, 0x0a, 0x7b, 0x7b, 0x65,
+	0x6c, 0x73, 0x65, 0x7d, 0x7d, 0x0a, 0x3c, 0x68,
+	0x31, 0x3e, 0x41, 0x6c, 0x6c, 0x20, 0x73, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x3c, 0x2f, 0x68, 0x31,
+	0x3e, 0x0a, 0x3c, 0x75, 0x6c, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e,
+	0x41, 0x6c, 0x6c, 0x53, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x7d, 0x7d, 0x0a, 0x3c, 0x6c, 0x69, 0x3e,
+	0x3c, 0x61, 0x20, 0x68, 0x72, 0x65, 0x66, 0x3d,
+	0x22, 0x7b, 0x7b, 0x2e, 0x55, 0x52, 0x4c, 0x7d,
+	0x7d, 0x22, 0x3e, 0x7b, 0x7b, 0x2e, 0x4e, 0x61,
+	0x6d, 0x65, 0x7d, 0x7d, 0x3c, 0x2f, 0x61, 0x3e,
+	0x20, 0x28, 0x7b, 0x7b, 0x2e, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x7d, 0x7d, 0x29, 0x3c, 0x2f, 0x6c,
+	0x69, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6c, 0x73,
+	0x65, 0x7d, 0x7d, 0x3c, 0x6c, 0x69, 0x3e, 0x54,
+	0x68, 0x65, 0x72, 0x65, 0x20, 0x61, 0x72, 0x65,
+	0x20, 0x6e, 0x6f, 0x20, 0x73, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x20, 0x6f, 0x6e, 0x20, 0x74, 0x68,
+	0x69, 0x73, 0x20, 0x62, 0x6c, 0x6f, 0x67, 0x21,
+	0x3c, 0x2f, 0x6c, 0x69, 0x3e, 0x7b, 0x7b, 0x65,
+	0x6e, 0x64, 0x7d, 0x7d, 0x0a, 0x3c, 0x2f, 0x75,
+	0x6c, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64,
+	0x7d, 0x7d, 0x0a, 0x3c, 0x2f, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x7b, 0x7b,
+	0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a,
 }
 
 var baseTemplatesStyleGohtml = []byte{
@@ -3816,19 +4376,95 @@ var baseTemplatesStyleGohtml = []byte{
 	0x22, 0x20, 0x74, 0x79, 0x70, 0x65, 0x3d, 0x22,
 	0x74, 0x65, 0x78, 0x74, 0x2f, 0x63, 0x73, 0x73,
 	0x22, 0x20, 0x68, 0x72, 0x65, 0x66, 0x3d, 0x22,
-	0x2f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x2f,
-	0x63, 0x73, 0x73, 0x2f, 0x62, 0x72, 0x6f, 0x67,
-	0x2e, 0x63, 0x73, 0x73, 0x22, 0x3e, 0x3c, 0x2f,
-	0x6c, 0x69, 0x6e, 0x6b, 0x3e, 0x0a, 0x3c, 0x6c,
-	0x69, 0x6e, 0x6b, 0x20, 0x72, 0x65, 0x6c, 0x3d,
-	0x22, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x73, 0x68,
-	0x65, 0x65, 0x74, 0x22, 0x20, 0x74, 0x79, 0x70,
-	0x65, 0x3d, 0x22, 0x74, 0x65, 0x78, 0x74, 0x2f,
-	0x63, 0x73, 0x73, 0x22, 0x20, 0x68, 0x72, 0x65,
-	0x66, 0x3d, 0x22, 0x2f, 0x61, 0x73, 0x73, 0x65,
-	0x74, 0x73, 0x2f, 0x63, 0x73, 0x73, 0x2f, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x73,
-	0x73, 0x22, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e,
+	0x7b, 0x7b, 0x61, 0x73, 0x73, 0x65, 0x74, 0x20,
+	0x22, 0x63, 0x73, 0x73, 0x2f, 0x62, 0x72, 0x6f,
+	0x67, 0x2e, 0x63, 0x73, 0x73, 0x22, 0x7d, 0x7d,
+	0x22, 0x3e, 0x3c, 0x2f, 0x6c, 0x69, 0x6e, 0x6b,
+	0x3e, 0x0a, 0x3c, 0x6c, 0x69, 0x6e, 0x6b, 0x20,
+	0x72, 0x65, 0x6c, 0x3d, 0x22, 0x73, 0x74, 0x79,
+	0x6c, 0x65, 0x73, 0x68, 0x65, 0x65, 0x74, 0x22,
+	0x20, 0x74, 0x79, 0x70, 0x65, 0x3d, 0x22, 0x74,
+	0x65, 0x78, 0x74, 0x2f, 0x63, 0x73, 0x73, 0x22,
+	0x20, 0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x7b,
+	0x7b, 0x61, 0x73, 0x73, 0x65, 0x74, 0x20, 0x22,
+	0x63, 0x73, 0x73, 0x2f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x73, 0x73, 0x22, 0x7d,
+	0x7d, 0x22, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e,
+	0x64, 0x7d, 0x7d, 0x0a,
+}
+
+var baseTemplatesTagGohtml = []byte{
+	0x7b, 0x7b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65,
+	0x20, 0x22, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x7d, 0x7d, 0x0a, 0x3c, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x3e, 0x0a, 0x7b,
+	0x7b, 0x69, 0x66, 0x20, 0x2e, 0x54, 0x61, 0x67,
+	0x7d, 0x7d, 0x0a, 0x3c, 0x68, 0x31, 0x3e, 0x7b,
+	0x7b, 0x2e, 0x54, 0x61, 0x67, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x68, 0x31, 0x3e, 0x0a, 0x7b, 0x7b, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x20, 0x2e, 0x50, 0x6f,
+	0x73, 0x74, 0x73, 0x7d, 0x7d, 0x0a, 0x3c, 0x68,
+	0x32, 0x3e, 0x3c, 0x61, 0x20, 0x68, 0x72, 0x65,
+	0x66, 0x3d, 0x22, 0x7b, 0x7b, 0x2e, 0x50, 0x65,
+	0x72, 0x6d, 0x61, 0x6c, 0x69, 0x6e, 0x6b, 0x7d,
+	0x7d, 0x22, 0x3e, 0x7b, 0x7b, 0x2e, 0x54, 0x69,
+	0x74, 0x6c, 0x65, 0x7d, 0x7d, 0x3c, 0x2f, 0x61,
+	0x3e, 0x3c, 0x2f, 0x68, 0x32, 0x3e, 0x0a, 0x3c,
+	0x70, 0x3e, 0x3c, 0x73, 0x6d, 0x61, 0x6c, 0x6c,
+	0x3e, 0x42, 0x79, 0x20, 0x7b, 0x7b, 0x2e, 0x41,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x7d, 0x7d, 0x2c,
+	0x20, 0x7b, 0x7b, 0x2e, 0x44, 0x61, 0x74, 0x65,
+	0x2e, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79,
+	0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2e, 0x44, 0x61,
+	0x74, 0x65, 0x2e, 0x44, 0x61, 0x79, 0x7d, 0x7d,
+	0x20, 0x7b, 0x7b, 0x2e, 0x44, 0x61, 0x74, 0x65,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x7d, 0x7d,
+	0x20, 0x7b, 0x7b, 0x2e, 0x44, 0x61, 0x74, 0x65,
+	0x2e, 0x59, 0x65, 0x61, 0x72, 0x7d, 0x7d, 0x3c,
+	0x2f, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x3c,
+	0x2f, 0x70, 0x3e, 0x0a, 0x3c, 0x70, 0x3e, 0x3c,
+	0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x3e, 0x7b, 0x7b,
+	0x2e, 0x41, 0x62, 0x73, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x7d, 0x7d, 0x3c, 0x2f, 0x73, 0x6d, 0x61,
+	0x6c, 0x6c, 0x3e, 0x3c, 0x2f, 0x70, 0x3e, 0x0a,
+	0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d, 0x0a,
+	0x3c, 0x70, 0x3e, 0x53, 0x65, 0x65, 0x20, 0x61,
+	0x6c, 0x6c, 0x20, 0x74, 0x68, 0x65, 0x20, 0x3c,
+	0x61, 0x20, 0x68, 0x72, 0x65, 0x66, 0x3d, 0x22,
+	0x2f, 0x7b, 0x7b, 0x2e, 0x54, 0x61, 0x78, 0x6f,
+	0x6e, 0x6f, 0x6d, 0x79, 0x7d, 0x7d, 0x2f, 0x22,
+	0x3e, 0x7b, 0x7b, 0x2e, 0x54, 0x61, 0x78, 0x6f,
+	0x6e, 0x6f, 0x6d, 0x79, 0x7d, 0x7d, 0x3c, 0x2f,
+	0x61, 0x3e, 0x2e, 0x3c, 0x2f, 0x70, 0x3e, 0x0a,
+	0x7b, 0x7b, 0x65, 0x6c, 0x73, 0x65, 0x7d, 0x7d,
+	0x0a, 0x3c, 0x68, 0x31, 0x3e, 0x41, 0x6c, 0x6c,
+	0x20, 0x7b, 0x7b, 0x2e, 0x54, 0x61, 0x78, 0x6f,
+	0x6e, 0x6f, 0x6d, 0x79, 0x7d, 0x7d, 0x3c, 0x2f,
+	0x68, 0x31, 0x3e, 0x0a, 0x3c, 0x75, 0x6c, 0x3e,
+	0x0a, 0x7b, 0x7b, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x20, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x7d, 0x7d,
+	0x0a, 0x3c, 0x6c, 0x69, 0x3e, 0x3c, 0x61, 0x20,
+	0x68, 0x72, 0x65, 0x66, 0x3d, 0x22, 0x2f, 0x7b,
+	0x7b, 0x24, 0x2e, 0x54, 0x61, 0x78, 0x6f, 0x6e,
+	0x6f, 0x6d, 0x79, 0x7d, 0x7d, 0x2f, 0x7b, 0x7b,
+	0x75, 0x72, 0x6c, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x20, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x7d, 0x7d,
+	0x22, 0x3e, 0x7b, 0x7b, 0x2e, 0x4e, 0x61, 0x6d,
+	0x65, 0x7d, 0x7d, 0x3c, 0x2f, 0x61, 0x3e, 0x20,
+	0x28, 0x7b, 0x7b, 0x2e, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x7d, 0x7d, 0x29, 0x3c, 0x2f, 0x6c, 0x69,
+	0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6c, 0x73, 0x65,
+	0x7d, 0x7d, 0x3c, 0x6c, 0x69, 0x3e, 0x54, 0x68,
+	0x65, 0x72, 0x65, 0x20, 0x61, 0x72, 0x65, 0x20,
+	0x6e, 0x6f, 0x20, 0x7b, 0x7b, 0x2e, 0x54, 0x61,
+	0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x7d, 0x7d,
+	0x20, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x69, 0x73,
+	0x20, 0x62, 0x6c, 0x6f, 0x67, 0x21, 0x3c, 0x2f,
+	0x6c, 0x69, 0x3e, 0x7b, 0x7b, 0x65, 0x6e, 0x64,
+	0x7d, 0x7d, 0x0a, 0x3c, 0x2f, 0x75, 0x6c, 0x3e,
+	0x0a, 0x7b, 0x7b, 0x65, 0x6e, 0x64, 0x7d, 0x7d,
+	0x0a, 0x3c, 0x2f, 0x61, 0x72, 0x74, 0x69, 0x63,
+	0x6c, 0x65, 0x3e, 0x0a, 0x7b, 0x7b, 0x65, 0x6e,
 	0x64, 0x7d, 0x7d, 0x0a,
 }
 
@@ -3856,68 +4492,75 @@ var basePostsSampleMd = []byte{
 	0x20, 0x42, 0x72, 0x6f, 0x67, 0x22, 0x2c, 0x0a,
 	0x20, 0x20, 0x20, 0x20, 0x22, 0x6c, 0x61, 0x6e,
 	0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x3a, 0x20,
-	0x22, 0x65, 0x6e, 0x22, 0x0a, 0x7d, 0x0a, 0x23,
-	0x20, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x21, 0x21,
-	0x0a, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73,
-	0x20, 0x6d, 0x79, 0x20, 0x66, 0x69, 0x72, 0x73,
-	0x74, 0x20, 0x42, 0x72, 0x6f, 0x67, 0x20, 0x70,
-	0x6f, 0x73, 0x74, 0x2e, 0x20, 0x20, 0x49, 0x20,
-	0x72, 0x65, 0x61, 0x6c, 0x6c, 0x79, 0x20, 0x6c,
-	0x69, 0x6b, 0x65, 0x20, 0x62, 0x72, 0x6f, 0x67,
-	0x69, 0x6e, 0x67, 0x20, 0x61, 0x6e, 0x64, 0x20,
-	0x66, 0x65, 0x65, 0x6c, 0x69, 0x6e, 0x67, 0x20,
-	0x6c, 0x69, 0x6b, 0x65, 0x20, 0x49, 0x27, 0x6d,
-	0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x6c, 0x79,
-	0x20, 0x6f, 0x6e, 0x65, 0x20, 0x6f, 0x66, 0x20,
-	0x74, 0x68, 0x6f, 0x73, 0x65, 0x20, 0x42, 0x72,
-	0x6f, 0x67, 0x67, 0x65, 0x72, 0x73, 0x2e, 0x20,
-	0x20, 0x41, 0x74, 0x20, 0x6c, 0x61, 0x73, 0x74,
-	0x2c, 0x20, 0x49, 0x27, 0x6d, 0x20, 0x70, 0x61,
-	0x72, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x20,
-	0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74,
-	0x79, 0x21, 0x0a, 0x0a, 0x60, 0x60, 0x60, 0x67,
-	0x6f, 0x0a, 0x66, 0x75, 0x6e, 0x63, 0x20, 0x48,
-	0x65, 0x6c, 0x6c, 0x6f, 0x28, 0x29, 0x20, 0x7b,
-	0x0a, 0x20, 0x20, 0x20, 0x20, 0x66, 0x6d, 0x74,
-	0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x66, 0x28,
-	0x22, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x3f, 0x22,
-	0x29, 0x0a, 0x7d, 0x0a, 0x60, 0x60, 0x60, 0x0a,
-	0x0a, 0x4d, 0x61, 0x79, 0x62, 0x65, 0x21, 0x20,
-	0x20, 0x5f, 0x5f, 0x57, 0x68, 0x6f, 0x20, 0x6b,
-	0x6e, 0x6f, 0x77, 0x73, 0x21, 0x21, 0x21, 0x5f,
-	0x5f, 0x20, 0x20, 0x48, 0x6f, 0x70, 0x65, 0x66,
-	0x75, 0x6c, 0x6c, 0x79, 0x20, 0x5b, 0x74, 0x68,
-	0x69, 0x73, 0x20, 0x77, 0x69, 0x6c, 0x6c, 0x20,
-	0x62, 0x65, 0x20, 0x61, 0x20, 0x6c, 0x69, 0x6e,
-	0x6b, 0x5d, 0x5b, 0x31, 0x5d, 0x2e, 0x0a, 0x0a,
-	0x3e, 0x20, 0x44, 0x6f, 0x6e, 0x27, 0x74, 0x20,
-	0x63, 0x6c, 0x69, 0x63, 0x6b, 0x20, 0x69, 0x74,
-	0x21, 0x21, 0x0a, 0x0a, 0x5f, 0x53, 0x68, 0x68,
-	0x68, 0x68, 0x5f, 0x2e, 0x0a, 0x0a, 0x23, 0x23,
-	0x20, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73,
-	0x20, 0x77, 0x68, 0x79, 0x20, 0x41, 0x6e, 0x74,
-	0x6f, 0x69, 0x6e, 0x65, 0x20, 0x69, 0x73, 0x20,
-	0x67, 0x72, 0x65, 0x61, 0x74, 0x0a, 0x0a, 0x2a,
-	0x20, 0x48, 0x65, 0x20, 0x68, 0x61, 0x73, 0x20,
-	0x61, 0x20, 0x6e, 0x69, 0x63, 0x65, 0x20, 0x62,
-	0x65, 0x61, 0x72, 0x64, 0x2e, 0x0a, 0x2a, 0x20,
-	0x48, 0x65, 0x20, 0x68, 0x61, 0x73, 0x20, 0x61,
-	0x20, 0x6e, 0x69, 0x63, 0x65, 0x20, 0x77, 0x61,
-	0x79, 0x20, 0x6f, 0x66, 0x20, 0x6d, 0x69, 0x73,
-	0x70, 0x65, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x20,
-	0x45, 0x6e, 0x67, 0x6c, 0x69, 0x73, 0x68, 0x2e,
-	0x0a, 0x2a, 0x20, 0x48, 0x65, 0x20, 0x70, 0x72,
-	0x65, 0x66, 0x65, 0x72, 0x73, 0x20, 0x53, 0x74,
-	0x61, 0x72, 0x20, 0x54, 0x72, 0x65, 0x6b, 0x20,
-	0x74, 0x6f, 0x20, 0x53, 0x74, 0x61, 0x72, 0x20,
-	0x57, 0x61, 0x72, 0x73, 0x2e, 0x0a, 0x0a, 0x5b,
-	0x31, 0x5d, 0x3a, 0x20, 0x68, 0x74, 0x74, 0x70,
-	0x73, 0x3a, 0x2f, 0x2f, 0x65, 0x6e, 0x2e, 0x77,
-	0x69, 0x6b, 0x69, 0x70, 0x65, 0x64, 0x69, 0x61,
-	0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x77, 0x69, 0x6b,
-	0x69, 0x2f, 0x42, 0x6f, 0x72, 0x67, 0x5f, 0x28,
-	0x53, 0x74, 0x61, 0x72, 0x5f, 0x54, 0x72, 0x65,
-	0x6b, 0x29, 0x0a,
+	0x22, 0x65, 0x6e, 0x22, 0x2c, 0x0a, 0x20, 0x20,
+	0x20, 0x20, 0x22, 0x74, 0x61, 0x67, 0x73, 0x22,
+	0x3a, 0x20, 0x5b, 0x22, 0x62, 0x72, 0x6f, 0x67,
+	0x22, 0x2c, 0x20, 0x22, 0x68, 0x65, 0x6c, 0x6c,
+	0x6f, 0x22, 0x5d, 0x2c, 0x0a, 0x20, 0x20, 0x20,
+	0x20, 0x22, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x22, 0x3a, 0x20, 0x5b,
+	0x22, 0x6d, 0x65, 0x74, 0x61, 0x22, 0x5d, 0x0a,
+	0x7d, 0x0a, 0x23, 0x20, 0x48, 0x65, 0x6c, 0x6c,
+	0x6f, 0x21, 0x21, 0x0a, 0x54, 0x68, 0x69, 0x73,
+	0x20, 0x69, 0x73, 0x20, 0x6d, 0x79, 0x20, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x20, 0x42, 0x72, 0x6f,
+	0x67, 0x20, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x20,
+	0x20, 0x49, 0x20, 0x72, 0x65, 0x61, 0x6c, 0x6c,
+	0x79, 0x20, 0x6c, 0x69, 0x6b, 0x65, 0x20, 0x62,
+	0x72, 0x6f, 0x67, 0x69, 0x6e, 0x67, 0x20, 0x61,
+	0x6e, 0x64, 0x20, 0x66, 0x65, 0x65, 0x6c, 0x69,
+	0x6e, 0x67, 0x20, 0x6c, 0x69, 0x6b, 0x65, 0x20,
+	0x49, 0x27, 0x6d, 0x0a, 0x66, 0x69, 0x6e, 0x61,
+	0x6c, 0x6c, 0x79, 0x20, 0x6f, 0x6e, 0x65, 0x20,
+	0x6f, 0x66, 0x20, 0x74, 0x68, 0x6f, 0x73, 0x65,
+	0x20, 0x42, 0x72, 0x6f, 0x67, 0x67, 0x65, 0x72,
+	0x73, 0x2e, 0x20, 0x20, 0x41, 0x74, 0x20, 0x6c,
+	0x61, 0x73, 0x74, 0x2c, 0x20, 0x49, 0x27, 0x6d,
+	0x20, 0x70, 0x61, 0x72, 0x74, 0x20, 0x6f, 0x66,
+	0x20, 0x61, 0x20, 0x63, 0x6f, 0x6d, 0x6d, 0x75,
+	0x6e, 0x69, 0x74, 0x79, 0x21, 0x0a, 0x0a, 0x60,
+	0x60, 0x60, 0x67, 0x6f, 0x0a, 0x66, 0x75, 0x6e,
+	0x63, 0x20, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x28,
+	0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20,
+	0x66, 0x6d, 0x74, 0x2e, 0x50, 0x72, 0x69, 0x6e,
+	0x74, 0x66, 0x28, 0x22, 0x48, 0x65, 0x6c, 0x6c,
+	0x6f, 0x3f, 0x22, 0x29, 0x0a, 0x7d, 0x0a, 0x60,
+	0x60, 0x60, 0x0a, 0x0a, 0x4d, 0x61, 0x79, 0x62,
+	0x65, 0x21, 0x20, 0x20, 0x5f, 0x5f, 0x57, 0x68,
+	0x6f, 0x20, 0x6b, 0x6e, 0x6f, 0x77, 0x73, 0x21,
+	0x21, 0x21, 0x5f, 0x5f, 0x20, 0x20, 0x48, 0x6f,
+	0x70, 0x65, 0x66, 0x75, 0x6c, 0x6c, 0x79, 0x20,
+	0x5b, 0x74, 0x68, 0x69, 0x73, 0x20, 0x77, 0x69,
+	0x6c, 0x6c, 0x20, 0x62, 0x65, 0x20, 0x61, 0x20,
+	0x6c, 0x69, 0x6e, 0x6b, 0x5d, 0x5b, 0x31, 0x5d,
+	0x2e, 0x0a, 0x0a, 0x3e, 0x20, 0x44, 0x6f, 0x6e,
+	0x27, 0x74, 0x20, 0x63, 0x6c, 0x69, 0x63, 0x6b,
+	0x20, 0x69, 0x74, 0x21, 0x21, 0x0a, 0x0a, 0x5f,
+	0x53, 0x68, 0x68, 0x68, 0x68, 0x5f, 0x2e, 0x0a,
+	0x0a, 0x23, 0x23, 0x20, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x73, 0x20, 0x77, 0x68, 0x79, 0x20,
+	0x41, 0x6e, 0x74, 0x6f, 0x69, 0x6e, 0x65, 0x20,
+	0x69, 0x73, 0x20, 0x67, 0x72, 0x65, 0x61, 0x74,
+	0x0a, 0x0a, 0x2a, 0x20, 0x48, 0x65, 0x20, 0x68,
+	0x61, 0x73, 0x20, 0x61, 0x20, 0x6e, 0x69, 0x63,
+	0x65, 0x20, 0x62, 0x65, 0x61, 0x72, 0x64, 0x2e,
+	0x0a, 0x2a, 0x20, 0x48, 0x65, 0x20, 0x68, 0x61,
+	0x73, 0x20, 0x61, 0x20, 0x6e, 0x69, 0x63, 0x65,
+	0x20, 0x77, 0x61, 0x79, 0x20, 0x6f, 0x66, 0x20,
+	0x6d, 0x69, 0x73, 0x70, 0x65, 0x61, 0x6b, 0x69,
+	0x6e, 0x67, 0x20, 0x45, 0x6e, 0x67, 0x6c, 0x69,
+	0x73, 0x68, 0x2e, 0x0a, 0x2a, 0x20, 0x48, 0x65,
+	0x20, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x73,
+	0x20, 0x53, 0x74, 0x61, 0x72, 0x20, 0x54, 0x72,
+	0x65, 0x6b, 0x20, 0x74, 0x6f, 0x20, 0x53, 0x74,
+	0x61, 0x72, 0x20, 0x57, 0x61, 0x72, 0x73, 0x2e,
+	0x0a, 0x0a, 0x5b, 0x31, 0x5d, 0x3a, 0x20, 0x68,
+	0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x65,
+	0x6e, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x70, 0x65,
+	0x64, 0x69, 0x61, 0x2e, 0x6f, 0x72, 0x67, 0x2f,
+	0x77, 0x69, 0x6b, 0x69, 0x2f, 0x42, 0x6f, 0x72,
+	0x67, 0x5f, 0x28, 0x53, 0x74, 0x61, 0x72, 0x5f,
+	0x54, 0x72, 0x65, 0x6b, 0x29, 0x0a,
 }
 
 var basePagesAboutMd = []byte{
@@ -1,17 +1,14 @@
 package brogger
 
 import (
-	"compress/gzip"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
-	"path"
 	"runtime"
 	"strconv"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/aybabtme/log"
@@ -22,11 +19,15 @@ import (
 // brog posts and watches for changes in posts and templates.
 type Brog struct {
 	isProd      bool
+	drafts      bool // Lists and serves the posts that aren't published
 	Config      *Config
 	netList     net.Listener
 	tmplMngr    *templateManager
 	postMngr    *postManager
 	pageMngr    *postManager
+	assetMngr   *assetManager
+	redirects   *redirects
+	pages       *pageCache // Rendered pages, while serving
 	middlewares [](func(http.HandlerFunc) http.HandlerFunc)
 }
 
@@ -36,6 +37,29 @@ type appContent struct {
 	Languages []string
 	CurPost   *post
 	Redir     string
+	Taxonomy  string // Taxonomy listed by the tag template, "tags" or "categories"
+	Tag       string // Term whose posts are listed by the tag template
+	Tags      []tag  // Terms of the taxonomy, when no term is being listed
+
+	Pagination *pagination // Position of `Posts` among all the pages of the index
+
+	Query   string         // Search query
+	Results []searchResult // Posts matching `Query`, best match first
+
+	Archive []*archiveYear // Posts by year then month, most recent first
+	Period  string         // Year or month listed by the archive template, empty for all
+
+	Series       *series   // Series of `CurPost`, or listed by the series template
+	AllSeries    []*series // Series listed by the series template, when it lists no `Series`
+	PrevInSeries *post     // Part of `Series` before `CurPost`
+	NextInSeries *post     // Part of `Series` after `CurPost`
+	Related      []*post   // Posts like `CurPost`, most related first
+
+	ServerHighlighting bool // Whether code is highlighted in posts already, see `Config.Highlighting`
+
+	Drafts bool // Whether posts that aren't published are shown, see `post.IsDraft`
+
+	Error string // Details of the error shown by the 500 template, in development only
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -85,6 +109,10 @@ func (b *Brog) Close() error {
 		errHandler(b.tmplMngr.Close())
 	}
 
+	if b.assetMngr != nil {
+		errHandler(b.assetMngr.Close())
+	}
+
 	if len(errs) != 0 {
 		return fmt.Errorf("caught errors while closing, %v", errs)
 	}
@@ -122,6 +150,9 @@ func (b *Brog) ListenAndServe() error {
 
 	log.Info("brog is starting")
 
+	// Authors preview their drafts in development
+	b.drafts = !b.isProd
+
 	if err := b.startWatchers(); err != nil {
 		return fmt.Errorf("starting watchers, %v", err)
 	}
@@ -129,20 +160,34 @@ func (b *Brog) ListenAndServe() error {
 	// don't add middleware to prometheus/heartbeat
 	b.HandleFunc("/debug/metrics", b.prometheusHandler(prometheus.Handler().ServeHTTP, "srv", "metrics"))
 	b.HandleFunc("/heartbeat", b.prometheusHandler(b.heartBeat, "srv", "heartbeat"))
-	b.middlewares = append(b.middlewares, b.logHandlerFunc)
+	b.pages = newPageCache()
+	b.middlewares = append(b.middlewares, b.cacheHandlerFunc, b.logHandlerFunc)
 
 	// langSelect shouldn't have language middleware on it
 	b.HandleFunc("/changelang", b.prometheusHandler(b.langSelectFunc, "srv", "changelang"))
+	// feeds have their language in their path, not in a cookie
+	b.handleFeeds()
+	// search has its own query, which the language middleware can't parse,
+	// and crawlers don't care about languages
+	b.HandleFunc("/search", b.prometheusHandler(b.searchFunc, "srv", "search"))
+	b.HandleFunc("/search.json", b.prometheusHandler(b.searchJSONFunc, "srv", "search.json"))
+	b.HandleFunc(sitemapPath, b.prometheusHandler(b.sitemapFunc, "srv", "sitemap"))
+	b.HandleFunc(robotsPath, b.prometheusHandler(b.robotsFunc, "srv", "robots"))
 	b.middlewares = append(b.middlewares, b.langHandlerFunc)
 
 	b.HandleFunc("/posts/", b.prometheusHandler(b.postFunc, "srv", "posts"))
 	b.HandleFunc("/pages/", b.prometheusHandler(b.pageFunc, "srv", "pages"))
+	b.HandleFunc("/page/", b.prometheusHandler(b.pageOfIndexFunc, "srv", "page"))
+	b.HandleFunc(archivePrefix, b.prometheusHandler(b.archiveFunc, "srv", "archive"))
+	b.HandleFunc(seriesPrefix, b.prometheusHandler(b.seriesFunc, "srv", "series"))
+	b.HandleFunc(previewPrefix, b.prometheusHandler(b.previewFunc, "srv", "preview"))
+	b.handleTaxonomies()
 	b.HandleFunc("/", b.prometheusHandler(b.indexFunc, "srv", "all"))
 
 	fileServer := http.FileServer(http.Dir(b.Config.AssetPath))
-	http.Handle("/assets/", http.StripPrefix("/assets/",
+	http.Handle(assetsPrefix, http.StripPrefix(assetsPrefix,
 		b.prometheusHandler(
-			b.logHandlerFunc(b.gzipHandler(fileServer)),
+			b.logHandlerFunc(b.gzipHandler(b.assetHandler(fileServer))),
 			"srv", "assets",
 		),
 	))
@@ -165,21 +210,69 @@ func (b *Brog) ListenAndServe() error {
 // Helpers
 ////////////////////////////////////////////////////////////////////////////////
 
+// loadContent parses the templates, posts and pages once, without watching
+// them for changes.
+func (b *Brog) loadContent() error {
+	redirects, err := loadRedirects(RedirectsFilename)
+	if err != nil {
+		return fmt.Errorf("loading redirects, %v", err)
+	}
+	b.redirects = redirects
+
+	assetMngr, err := newAssetManager(b, b.Config.AssetPath)
+	if err != nil {
+		return fmt.Errorf("loading asset manager, %v", err)
+	}
+	b.assetMngr = assetMngr
+
+	tmplMngr, err := newTemplateManager(b, b.Config.TemplatePath)
+	if err != nil {
+		return fmt.Errorf("loading template manager, %v", err)
+	}
+	b.tmplMngr = tmplMngr
+
+	postMngr, err := newPostManager(b, b.Config.PostPath, b.Config.Permalink)
+	if err != nil {
+		return fmt.Errorf("loading post manager, %v", err)
+	}
+	b.postMngr = postMngr
+
+	pageMngr, err := newPostManager(b, b.Config.PagePath, pagePermalink)
+	if err != nil {
+		return fmt.Errorf("loading page manager, %v", err)
+	}
+	b.pageMngr = pageMngr
+
+	return nil
+}
+
 func (b *Brog) startWatchers() error {
 	log.Info("starting file watches")
 
+	redirects, err := loadRedirects(RedirectsFilename)
+	if err != nil {
+		return fmt.Errorf("loading redirects, %v", err)
+	}
+	b.redirects = redirects
+
+	assetMngr, err := startAssetManager(b, b.Config.AssetPath)
+	if err != nil {
+		return fmt.Errorf("starting asset manager, %v", err)
+	}
+	b.assetMngr = assetMngr
+
 	tmplMngr, err := startTemplateManager(b, b.Config.TemplatePath)
 	if err != nil {
 		return fmt.Errorf("starting template manager, %v", err)
 	}
 	b.tmplMngr = tmplMngr
 
-	postMngr, err := startPostManager(b, b.Config.PostPath)
+	postMngr, err := startPostManager(b, b.Config.PostPath, b.Config.Permalink)
 	if err != nil {
 		return fmt.Errorf("starting post manager, %v", err)
 	}
 
-	pageMngr, err := startPostManager(b, b.Config.PagePath)
+	pageMngr, err := startPostManager(b, b.Config.PagePath, pagePermalink)
 	if err != nil {
 		return fmt.Errorf("starting page manager, %v", err)
 	}
@@ -229,24 +322,8 @@ func (b *Brog) HandleFunc(path string, h http.HandlerFunc) {
 	for _, middleware := range b.middlewares {
 		h = middleware(h)
 	}
-	http.HandleFunc(path, h)
-}
-
-//gzip handler for the assets files
-func (b *Brog) gzipHandler(h http.Handler) http.HandlerFunc {
-	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		if !strings.Contains(req.Header.Get("Accept-Encoding"), "gzip") {
-			h.ServeHTTP(w, req)
-			return
-		}
-		w.Header().Set("Content-Encoding", "gzip")
-		gz := gzip.NewWriter(w)
-		defer func() {
-			_ = gz.Close()
-		}()
-		gzrw := gzipResponseWriter{Writer: gz, ResponseWriter: w}
-		h.ServeHTTP(gzrw, req)
-	})
+	// Outermost, to recover from panics in the middlewares too
+	http.HandleFunc(path, b.recoverHandlerFunc(h))
 }
 
 // prometheus handler for matrics
@@ -274,80 +351,170 @@ func (b *Brog) heartBeat(rw http.ResponseWriter, req *http.Request) {
 func (b *Brog) indexFunc(rw http.ResponseWriter, req *http.Request) {
 
 	lang, _ := b.extractLanguage(req)
-	pages := b.pageMngr.GetAllPostsWithLanguage(lang)
-	posts := b.postMngr.GetAllPostsWithLanguage(lang)
 
-	data := appContent{
-		Posts:     posts,
-		Pages:     pages,
-		Languages: b.Config.Languages,
-		CurPost:   nil,
-	}
-
-	b.tmplMngr.DoWithIndex(func(t *template.Template) {
-		if err := t.Execute(rw, data); err != nil {
-			log.Err(err).Error("couldn't render index template")
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
+	// Posts can have permalinks anywhere, not only under /posts/
+	reqPath := strings.SplitN(req.RequestURI, "?", 2)[0]
+	if reqPath != "/" {
+		if post, ok := b.getPostAt(b.postMngr, reqPath); ok {
+			b.servePost(rw, req, lang, b.postMngr, post)
 			return
 		}
-	})
+		if b.serveBundleFile(rw, req, b.postMngr, reqPath) {
+			return
+		}
+		if b.redirectMoved(rw, req, reqPath) {
+			return
+		}
+		// Only the root is the index, other pages of it are under /page/
+		b.notFound(rw, req)
+		return
+	}
+
+	data, _ := b.indexContent(lang, 1)
+
+	if err := b.render(rw, req, b.tmplMngr.DoWithIndex, data); err != nil {
+		log.Err(err).Error("couldn't render index template")
+	}
 }
 
 func (b *Brog) postFunc(rw http.ResponseWriter, req *http.Request) {
 
 	lang, _ := b.extractLanguage(req)
-	pages := b.pageMngr.GetAllPostsWithLanguage(lang)
 
-	postID := path.Base(strings.SplitN(req.RequestURI, "?", 2)[0])
-	post, ok := b.postMngr.GetPost(postID)
-	if !ok {
-		http.NotFound(rw, req)
+	reqPath := strings.SplitN(req.RequestURI, "?", 2)[0]
+	if post, ok := b.getPostAt(b.postMngr, reqPath); ok {
+		b.servePost(rw, req, lang, b.postMngr, post)
 		return
 	}
 
-	data := appContent{
-		Posts:     nil,
-		Pages:     pages,
-		Languages: b.Config.Languages,
-		CurPost:   post,
+	if b.serveBundleFile(rw, req, b.postMngr, reqPath) {
+		return
 	}
 
-	b.tmplMngr.DoWithPost(func(t *template.Template) {
-		if err := t.Execute(rw, data); err != nil {
-			log.Err(err).KV("post.id", postID).Error("couldn't render post template")
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
-			return
-		}
-	})
+	if b.redirectMoved(rw, req, reqPath) {
+		return
+	}
+
+	// Links to /posts/<id> predate permalink patterns, send them where the
+	// post now lives
+	postID := strings.TrimPrefix(permalinkKey(reqPath), "/posts/")
+	if post, ok := b.getPost(b.postMngr, postID); ok {
+		http.Redirect(rw, req, post.Permalink(), http.StatusMovedPermanently)
+		return
+	}
+
+	b.notFound(rw, req)
 }
 
 func (b *Brog) pageFunc(rw http.ResponseWriter, req *http.Request) {
 
 	lang, _ := b.extractLanguage(req)
-	pages := b.pageMngr.GetAllPostsWithLanguage(lang)
 
-	pageID := path.Base(strings.SplitN(req.RequestURI, "?", 2)[0])
-	page, ok := b.pageMngr.GetPost(pageID)
+	reqPath := strings.SplitN(req.RequestURI, "?", 2)[0]
+	page, ok := b.getPostAt(b.pageMngr, reqPath)
 
 	if !ok {
-		http.NotFound(rw, req)
+		if b.serveBundleFile(rw, req, b.pageMngr, reqPath) {
+			return
+		}
+		if !b.redirectMoved(rw, req, reqPath) {
+			b.notFound(rw, req)
+		}
 		return
 	}
 
-	data := appContent{
+	b.servePost(rw, req, lang, b.pageMngr, page)
+}
+
+// servePost renders the post template for `cur`, either a post or a page
+// of `mngr`.
+func (b *Brog) servePost(rw http.ResponseWriter, req *http.Request, lang string, mngr *postManager, cur *post) {
+
+	data := b.postContent(lang, mngr, cur)
+
+	if err := b.render(rw, req, b.tmplMngr.DoWithPost, data); err != nil {
+		log.Err(err).KV("post.id", cur.GetID()).Error("couldn't render post template")
+	}
+}
+
+// baseContent is the data common to every template rendered for `lang`.
+func (b *Brog) baseContent(lang string) appContent {
+	return appContent{
 		Posts:     nil,
-		Pages:     pages,
+		Pages:     b.getAllPosts(b.pageMngr, lang),
 		Languages: b.Config.Languages,
-		CurPost:   page,
+		CurPost:   nil,
+		Drafts:    b.drafts,
+
+		ServerHighlighting: b.Config.Highlighting == serverHighlighting,
 	}
+}
 
-	b.tmplMngr.DoWithPost(func(t *template.Template) {
-		if err := t.Execute(rw, data); err != nil {
-			log.Err(err).KV("page.id", pageID).Error("couldn't render page template")
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
-			return
+// getAllPosts lists the posts of `mngr` in `lang`, drafts included if they
+// are being previewed.
+func (b *Brog) getAllPosts(mngr *postManager, lang string) []*post {
+	if b.drafts {
+		return mngr.GetAllPostsWithDrafts(lang)
+	}
+	return mngr.GetAllPostsWithLanguage(lang)
+}
+
+// getPostAt finds the post of `mngr` whose permalink is `urlpath`, even if
+// it's a draft when they are being previewed.
+func (b *Brog) getPostAt(mngr *postManager, urlpath string) (*post, bool) {
+	post, ok := mngr.GetPostAt(urlpath)
+	if !ok || (!b.drafts && post.IsDraft()) {
+		return nil, false
+	}
+	return post, true
+}
+
+// getPost finds post `id` of `mngr`, even if it's a draft when they are
+// being previewed.
+func (b *Brog) getPost(mngr *postManager, id string) (*post, bool) {
+	if b.drafts {
+		return mngr.GetPostOrDraft(id)
+	}
+	return mngr.GetPost(id)
+}
+
+// indexContent is the data rendered by the index template for `page` of the
+// posts in `lang`, or false if there is no such page.
+func (b *Brog) indexContent(lang string, page int) (appContent, bool) {
+	posts := b.getAllPosts(b.postMngr, lang)
+	posts, pager, ok := paginate(posts, page, b.Config.PostsPerPage)
+	if !ok {
+		return appContent{}, false
+	}
+
+	data := b.baseContent(lang)
+	data.Posts = posts
+	data.Pagination = pager
+	return data, true
+}
+
+// postContent is the data rendered by the post template when showing `cur`,
+// which can be either a post or a page of `mngr`. Pages aren't related to
+// posts, nor part of their series.
+func (b *Brog) postContent(lang string, mngr *postManager, cur *post) appContent {
+	data := b.baseContent(lang)
+	data.CurPost = cur
+	if mngr != b.postMngr {
+		return data
+	}
+	data.Related = b.postMngr.GetRelated(cur)
+
+	// Parts of a series are read in the language of the post
+	if s, ok := b.postMngr.GetSeries(cur.Series, cur.Language); ok {
+		data.Series = s
+		if i := s.indexOf(cur); i > 0 {
+			data.PrevInSeries = s.Posts[i-1]
 		}
-	})
+		if i := s.indexOf(cur); i >= 0 && i < len(s.Posts)-1 {
+			data.NextInSeries = s.Posts[i+1]
+		}
+	}
+	return data
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -402,13 +569,9 @@ func (b *Brog) langSelectFunc(rw http.ResponseWriter, req *http.Request) {
 		Redir:     redirpath,
 	}
 
-	b.tmplMngr.DoWithLangSelect(func(t *template.Template) {
-		if err := t.Execute(rw, data); err != nil {
-			log.Err(err).Error("couldn't render language selection template")
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
-			return
-		}
-	})
+	if err := b.render(rw, req, b.tmplMngr.DoWithLangSelect, data); err != nil {
+		log.Err(err).Error("couldn't render language selection template")
+	}
 }
 
 func (b *Brog) langHandlerFunc(h http.HandlerFunc) http.HandlerFunc {
@@ -10,9 +10,7 @@ import (
 func SetUpDefaultBrog() *Brog {
 	config := newDefaultConfig()
 	config.ConsoleVerbosity = "info"
-	logmux, _ := makeLogMux(config)
 	b := &Brog{
-		logMux: logmux,
 		Config: config,
 		isProd: false,
 	}
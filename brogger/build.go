@@ -0,0 +1,319 @@
+package brogger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/aybabtme/log"
+)
+
+// indexFilename is the file written for every directory-like URL, so that
+// any static file server can serve the output of `Build`.
+const indexFilename = "index.html"
+
+// notFoundFilename is the page static file servers answer with when there's
+// no file for a URL.
+const notFoundFilename = "404.html"
+
+// Build renders the whole brog into static files under `outdir`, using the
+// same templates and content that `ListenAndServe` would serve. If `outdir`
+// is empty, the build path of the config is used.
+func (b *Brog) Build(outdir string) error {
+	if outdir == "" {
+		outdir = b.Config.BuildPath
+	}
+	outdir = filepath.Clean(outdir)
+
+	if err := b.loadContent(); err != nil {
+		return fmt.Errorf("loading content, %v", err)
+	}
+
+	ll := log.KV("build.path", outdir)
+	ll.Info("building brog")
+
+	if err := b.buildIndex(outdir); err != nil {
+		return fmt.Errorf("building index, %v", err)
+	}
+
+	if err := b.buildPosts(outdir, b.postMngr); err != nil {
+		return fmt.Errorf("building posts, %v", err)
+	}
+
+	if err := b.buildPosts(outdir, b.pageMngr); err != nil {
+		return fmt.Errorf("building pages, %v", err)
+	}
+
+	if err := b.buildRedirects(outdir); err != nil {
+		return fmt.Errorf("building redirects, %v", err)
+	}
+
+	if err := b.buildArchive(outdir); err != nil {
+		return fmt.Errorf("building archive, %v", err)
+	}
+
+	if err := b.buildSeries(outdir); err != nil {
+		return fmt.Errorf("building series, %v", err)
+	}
+
+	if err := b.buildTaxonomies(outdir); err != nil {
+		return fmt.Errorf("building taxonomies, %v", err)
+	}
+
+	if err := b.buildFeeds(outdir); err != nil {
+		return fmt.Errorf("building feeds, %v", err)
+	}
+
+	if err := b.buildSitemap(outdir); err != nil {
+		return fmt.Errorf("building sitemap, %v", err)
+	}
+
+	// Served by most static hosts for the URLs that lead nowhere
+	notFound := filepath.Join(outdir, notFoundFilename)
+	if err := writeTemplate(notFound, b.tmplMngr.DoWithNotFound, b.baseContent("")); err != nil {
+		return fmt.Errorf("building not found page, %v", err)
+	}
+
+	if err := copyDir(b.Config.AssetPath, filepath.Join(outdir, "assets")); err != nil {
+		return fmt.Errorf("copying assets, %v", err)
+	}
+
+	if err := b.assetMngr.copyFingerprinted(filepath.Join(outdir, "assets")); err != nil {
+		return fmt.Errorf("copying fingerprinted assets, %v", err)
+	}
+
+	ll.Info("brog has been built")
+	return nil
+}
+
+func (b *Brog) buildIndex(outdir string) error {
+	for page := 1; ; page++ {
+		data, ok := b.indexContent("", page)
+		if !ok {
+			return nil
+		}
+		filename := filepath.Join(outdir, pageURL(page), indexFilename)
+		if err := writeTemplate(filename, b.tmplMngr.DoWithIndex, data); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *Brog) buildPosts(outdir string, mngr *postManager) error {
+	for _, post := range mngr.GetAllPosts() {
+		filename, err := urlFilename(outdir, post.Permalink())
+		if err != nil {
+			return err
+		}
+
+		data := b.postContent("", mngr, post)
+		if err := writeTemplate(filename, b.tmplMngr.DoWithPost, data); err != nil {
+			return err
+		}
+
+		if post.bundle != "" {
+			if err := b.copyBundle(post, filepath.Dir(filename)); err != nil {
+				return fmt.Errorf("copying page bundle of post '%s', %v", post.GetID(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Brog) buildArchive(outdir string) error {
+	years, _ := b.postMngr.GetArchive("", 0, 0)
+
+	write := func(year int, month time.Month) error {
+		data, ok := b.archiveContent("", year, month)
+		if !ok {
+			return nil
+		}
+		filename := filepath.Join(outdir, archiveURL(year, month), indexFilename)
+		return writeTemplate(filename, b.tmplMngr.DoWithArchive, data)
+	}
+
+	if err := write(0, 0); err != nil {
+		return err
+	}
+	for _, y := range years {
+		if err := write(y.Year, 0); err != nil {
+			return err
+		}
+		for _, m := range y.Months {
+			if err := write(m.Year, m.Month); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Brog) buildSeries(outdir string) error {
+	all := b.postMngr.GetAllSeries("")
+	if len(all) == 0 {
+		return nil
+	}
+
+	data, _ := b.seriesContent("", "")
+	if err := writeTemplate(filepath.Join(outdir, seriesPrefix, indexFilename), b.tmplMngr.DoWithSeries, data); err != nil {
+		return err
+	}
+	for _, s := range all {
+		filename, err := urlFilename(outdir, s.URL())
+		if err != nil {
+			return err
+		}
+		data, _ := b.seriesContent("", s.Name)
+		if err := writeTemplate(filename, b.tmplMngr.DoWithSeries, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Brog) buildTaxonomies(outdir string) error {
+	for _, kind := range allTaxonomies {
+		tags := b.postMngr.GetTags(kind, "")
+		if len(tags) == 0 {
+			continue
+		}
+
+		filename := filepath.Join(outdir, kind, indexFilename)
+		if err := writeTemplate(filename, b.tmplMngr.DoWithTag, b.tagsContent("", kind, "")); err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			filename, err := urlFilename(outdir, "/"+kind+"/"+url.QueryEscape(tag.Name))
+			if err != nil {
+				return err
+			}
+			if err := writeTemplate(filename, b.tmplMngr.DoWithTag, b.tagsContent("", kind, tag.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Brog) buildFeeds(outdir string) error {
+	for _, lang := range b.feedLanguages() {
+		buf := bytes.NewBuffer(nil)
+		if err := b.renderAtom(buf, lang); err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(outdir, feedPath(lang, atomExt)), buf.Bytes()); err != nil {
+			return err
+		}
+
+		buf.Reset()
+		if err := b.renderRSS(buf, lang); err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(outdir, feedPath(lang, rssExt)), buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Brog) buildSitemap(outdir string) error {
+	buf := bytes.NewBuffer(nil)
+	if err := b.renderSitemap(buf); err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(outdir, sitemapPath), buf.Bytes()); err != nil {
+		return err
+	}
+
+	buf.Reset()
+	if err := b.renderRobots(buf); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(outdir, robotsPath), buf.Bytes())
+}
+
+// urlFilename is the file under `outdir` that a static file server serves
+// for `urlpath`.
+func urlFilename(outdir, urlpath string) (string, error) {
+	unescaped, err := url.PathUnescape(urlpath)
+	if err != nil {
+		return "", fmt.Errorf("unescaping URL '%s', %v", urlpath, err)
+	}
+	return filepath.Join(outdir, filepath.FromSlash(unescaped), indexFilename), nil
+}
+
+// writeTemplate executes the template handed out by `doWith` and writes the
+// result to `filename`, creating its parent directories if needed.
+func writeTemplate(filename string, doWith func(func(*template.Template)), data appContent) error {
+	buf := bytes.NewBuffer(nil)
+
+	var err error
+	doWith(func(t *template.Template) {
+		err = t.Execute(buf, data)
+	})
+	if err != nil {
+		return fmt.Errorf("rendering template for '%s', %v", filename, err)
+	}
+
+	return writeFile(filename, buf.Bytes())
+}
+
+func writeFile(filename string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return fmt.Errorf("creating directory for '%s', %v", filename, err)
+	}
+	if err := ioutil.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("writing '%s', %v", filename, err)
+	}
+	return nil
+}
+
+// copyDir copies every file under `src` to the same relative location under
+// `dst`.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(fullpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relpath, err := filepath.Rel(src, fullpath)
+		if err != nil {
+			return err
+		}
+		return copyFile(fullpath, filepath.Join(dst, relpath))
+	})
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("creating directory for '%s', %v", dst, err)
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("opening '%s', %v", src, err)
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("creating '%s', %v", dst, err)
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("copying '%s' to '%s', %v", src, dst, err)
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,87 @@
+package brogger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	_ = os.Chdir("base")
+	defer func() { _ = os.Chdir("..") }()
+
+	outdir, err := ioutil.TempDir("", "brog_build")
+	if err != nil {
+		t.Fatalf("Can't create build directory: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(outdir) }()
+
+	b := SetUpDefaultBrog()
+	if err := b.Build(outdir); err != nil {
+		t.Fatalf("Error encountered building brog: %v", err)
+	}
+	defer func() { _ = b.Close() }()
+
+	index, err := ioutil.ReadFile(filepath.Join(outdir, "index.html"))
+	if err != nil {
+		t.Fatalf("Index wasn't built: %v", err)
+	}
+	if len(index) == 0 {
+		t.Error("Built index is empty")
+	}
+
+	if !fileExists(filepath.Join(outdir, "assets", "css", "brog.css")) {
+		t.Error("Assets weren't copied to the build directory")
+	}
+	css := regexp.MustCompile(`/assets/css/brog\.[0-9a-f]+\.css`).Find(index)
+	if css == nil {
+		t.Error("Built index doesn't link to the fingerprinted stylesheet")
+	} else if !fileExists(filepath.Join(outdir, filepath.FromSlash(string(css)))) {
+		t.Errorf("Fingerprinted stylesheet %s wasn't copied to the build directory", css)
+	}
+
+	if !fileExists(filepath.Join(outdir, notFoundFilename)) {
+		t.Error("Not found page wasn't built")
+	}
+
+	// sample.md is invisible and must not be published
+	if fileExists(filepath.Join(outdir, "posts", "sample", "index.html")) {
+		t.Error("Invisible post was built")
+	}
+}
+
+func TestBuildDoesntReplicateTemplates(t *testing.T) {
+	_ = os.Chdir("base")
+	defer func() { _ = os.Chdir("..") }()
+
+	outdir, err := ioutil.TempDir("", "brog_build")
+	if err != nil {
+		t.Fatalf("Can't create build directory: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(outdir) }()
+	tmpldir, err := ioutil.TempDir("", "brog_templates")
+	if err != nil {
+		t.Fatalf("Can't create template directory: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpldir) }()
+
+	b := SetUpDefaultBrog()
+	b.Config.TemplatePath = tmpldir
+	if err := b.Build(outdir); err != nil {
+		t.Fatalf("Error encountered building brog without templates: %v", err)
+	}
+	defer func() { _ = b.Close() }()
+
+	files, err := ioutil.ReadDir(tmpldir)
+	if err != nil {
+		t.Fatalf("Can't list template directory: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Build wrote %d templates in the template path", len(files))
+	}
+	if !fileExists(filepath.Join(outdir, "index.html")) {
+		t.Error("Index wasn't built with the default templates")
+	}
+}
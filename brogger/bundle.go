@@ -0,0 +1,166 @@
+package brogger
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// bundleIndexName is the name, without extension, of the post of a page
+// bundle: a directory holding a post along with its images and other files.
+const bundleIndexName = "index"
+
+// markdownExtensions are the extensions of post files, on top of the one in
+// the config.
+var markdownExtensions = []string{".md", ".markdown", ".mkd"}
+
+// isMarkdown tells if `filename` has the extension of a post file.
+func (b *Brog) isMarkdown(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == b.Config.PostFileExt {
+		return true
+	}
+	for _, val := range markdownExtensions {
+		if ext == val {
+			return true
+		}
+	}
+	return false
+}
+
+// bundleIndex finds the post of the page bundle `dirname`, or false if it
+// isn't a page bundle.
+func (b *Brog) bundleIndex(dirname string) (string, bool) {
+	for _, ext := range append([]string{b.Config.PostFileExt}, markdownExtensions...) {
+		filename := filepath.Join(dirname, bundleIndexName+ext)
+		if fileExists(filename) {
+			return filename, true
+		}
+	}
+	return "", false
+}
+
+// bundleOf finds the page bundle `filename` is part of, the outermost
+// directory under the posts directory with an index, or false if it isn't
+// part of one.
+func (p *postManager) bundleOf(filename string) (string, bool) {
+	rel, err := filepath.Rel(p.path, filepath.Dir(filename))
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	dirname := filepath.Clean(p.path)
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		dirname = filepath.Join(dirname, part)
+		if _, ok := p.brog.bundleIndex(dirname); ok {
+			return dirname, true
+		}
+	}
+	return "", false
+}
+
+// linkAttr matches the attributes of HTML elements that hold a URL.
+var linkAttr = regexp.MustCompile(`\b(src|href)=("[^"]*"|'[^']*')`)
+
+// rewriteRelativeURLs makes the relative links and images of `htmlContent`
+// relative to `base` instead, an absolute URL path ending with a slash.
+func rewriteRelativeURLs(htmlContent, base string) string {
+	return resolveURLs(htmlContent, base, func(val string) bool {
+		return strings.HasPrefix(val, "/") || strings.HasPrefix(val, "#") || strings.HasPrefix(val, "?")
+	})
+}
+
+// resolveURLs resolves the links and images of `htmlContent` that aren't
+// absolute against `base`, but those for which `skip` is true.
+func resolveURLs(htmlContent, base string, skip func(val string) bool) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return htmlContent
+	}
+	return linkAttr.ReplaceAllStringFunc(htmlContent, func(attr string) string {
+		parts := linkAttr.FindStringSubmatch(attr)
+		name, quoted := parts[1], parts[2]
+		quote, val := quoted[:1], quoted[1:len(quoted)-1]
+
+		if val == "" || skip(val) {
+			return attr
+		}
+		ref, err := url.Parse(val)
+		if err != nil || ref.Scheme != "" || ref.Host != "" {
+			return attr
+		}
+		return name + "=" + quote + baseURL.ResolveReference(ref).String() + quote
+	})
+}
+
+// bundleURL is the URL path under which the files of the page bundle of `p`
+// are served, ending with a slash.
+func (p *post) bundleURL() string {
+	return strings.TrimSuffix(p.Permalink(), "/") + "/"
+}
+
+// serveBundleFile serves the file of a page bundle of `mngr` at `urlpath`. It
+// returns false if `urlpath` isn't under the URL of a page bundle.
+func (b *Brog) serveBundleFile(rw http.ResponseWriter, req *http.Request, mngr *postManager, urlpath string) bool {
+	// The post is at one of the parent URLs of its files
+	var post *post
+	for dir := urlpath; post == nil; {
+		i := strings.LastIndex(strings.TrimSuffix(dir, "/"), "/")
+		if i <= 0 {
+			return false
+		}
+		dir = dir[:i]
+		post, _ = b.getPostAt(mngr, dir)
+	}
+	if post.bundle == "" {
+		return false
+	}
+
+	rel, err := url.PathUnescape(strings.TrimPrefix(urlpath, post.bundleURL()))
+	if err != nil || b.isMarkdown(rel) {
+		// Posts are served rendered, not as their source
+		b.notFound(rw, req)
+		return true
+	}
+	bundle := filepath.Clean(post.bundle)
+	filename := filepath.Join(bundle, filepath.FromSlash(rel))
+	if !strings.HasPrefix(filename, bundle+string(os.PathSeparator)) {
+		b.notFound(rw, req)
+		return true
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		b.notFound(rw, req)
+		return true
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		b.notFound(rw, req)
+		return true
+	}
+
+	http.ServeContent(rw, req, info.Name(), info.ModTime(), file)
+	return true
+}
+
+// copyBundle copies the files of the page bundle of `p` to `dst`, the
+// directory of the built post, leaving out its Markdown files.
+func (b *Brog) copyBundle(p *post, dst string) error {
+	return filepath.Walk(p.bundle, func(fullpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || b.isMarkdown(fullpath) {
+			return nil
+		}
+		rel, err := filepath.Rel(p.bundle, fullpath)
+		if err != nil {
+			return err
+		}
+		return copyFile(fullpath, filepath.Join(dst, rel))
+	})
+}
@@ -0,0 +1,103 @@
+package brogger
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func SetUpBundle(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "brog-bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"my-trip/index.md":       "{\"title\": \"My trip\", \"date\": \"2014-06-01T00:00:00Z\"}\n![Beach](photo.jpg) [map](maps/route.png) [home](/) [top](#top)\n",
+		"my-trip/photo.jpg":      "jpeg",
+		"my-trip/notes.md":       "not a post",
+		"empty/readme.txt":       "not a bundle",
+		"standalone.md":          "{\"title\": \"Standalone\", \"date\": \"2014-06-02T00:00:00Z\"}\nHello\n",
+		"my-trip/maps/route.png": "png",
+	}
+	for name, content := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadBundle(t *testing.T) {
+	dir := SetUpBundle(t)
+	defer os.RemoveAll(dir)
+
+	b := SetUpDefaultBrog()
+	pmgr, err := newPostManager(b, dir, DefaultPermalink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(pmgr.GetAllPosts()); n != 2 {
+		t.Fatalf("Expected the bundle and the standalone post, got %d posts", n)
+	}
+
+	post, ok := pmgr.GetPost("my-trip")
+	if !ok {
+		t.Fatal("Bundle isn't named after its directory")
+	}
+	for _, want := range []string{
+		`src="/posts/my-trip/photo.jpg"`,
+		`href="/posts/my-trip/maps/route.png"`,
+		`href="/"`,
+		`href="#top"`,
+	} {
+		if !strings.Contains(post.Content, want) {
+			t.Errorf("Expected %s in the content, got %s", want, post.Content)
+		}
+	}
+}
+
+func TestServeBundleFile(t *testing.T) {
+	dir := SetUpBundle(t)
+	defer os.RemoveAll(dir)
+
+	b := SetUpDefaultBrog()
+	var err error
+	b.postMngr, err = newPostManager(b, dir, DefaultPermalink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for urlpath, want := range map[string]int{
+		"/posts/my-trip/photo.jpg":        http.StatusOK,
+		"/posts/my-trip/maps/route.png":   http.StatusOK,
+		"/posts/my-trip/notes.md":         http.StatusNotFound,
+		"/posts/my-trip/index.md":         http.StatusNotFound,
+		"/posts/my-trip/maps":             http.StatusNotFound,
+		"/posts/my-trip/../standalone.md": http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", urlpath, nil)
+		if !b.serveBundleFile(rec, req, b.postMngr, urlpath) {
+			t.Errorf("'%s' isn't handled as a file of the bundle", urlpath)
+			continue
+		}
+		if rec.Code != want {
+			t.Errorf("'%s' answered %d, expected %d", urlpath, rec.Code, want)
+		}
+	}
+
+	for _, urlpath := range []string{"/posts/standalone/photo.jpg", "/posts/unknown/photo.jpg"} {
+		req, _ := http.NewRequest("GET", urlpath, nil)
+		if b.serveBundleFile(httptest.NewRecorder(), req, b.postMngr, urlpath) {
+			t.Errorf("'%s' is handled as a file of a bundle", urlpath)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -25,11 +26,21 @@ var (
 	DefaultPostPath       = "posts" + string(os.PathSeparator)
 	DefaultPagePath       = "pages" + string(os.PathSeparator)
 	DefaultAssetPath      = "assets" + string(os.PathSeparator)
+	DefaultBuildPath      = "public" + string(os.PathSeparator)
 	DefaultPostFileExt    = ".md"
 	DefaultRewriteInvalid = true  // True so that brog has stable default
 	DefaultRewriteMissing = true  // True so that brog has stable default
 	DefaultMultilingual   = false // False because blogs are usually unilingual
 	DefaultLanguages      = []string{"en"}
+	DefaultBaseURL        = "http://localhost:3000"
+	DefaultSiteTitle      = "We Are Brog"
+	DefaultFeedItemCount  = 20
+	DefaultPostsPerPage   = 10
+	DefaultFrontMatter    = jsonFrontMatter
+	DefaultPermalink      = "/posts/" + permalinkSlug
+	DefaultHighlighting   = clientHighlighting
+	DefaultWatcher        = notifyWatcher
+	DefaultPollInterval   = "2s"
 )
 
 // Config contains all the settings that a Brog uses to watch and create
@@ -43,12 +54,23 @@ type Config struct {
 	PostPath         string   `json:"postPath"`
 	PagePath         string   `json:"pagePath"`
 	AssetPath        string   `json:"assetPath"`
+	BuildPath        string   `json:"buildPath"`
 	PostFileExt      string   `json:"postFileExtension"`
 	ConsoleVerbosity string   `json:"consoleVerbosity"`
 	RewriteInvalid   bool     `json:"rewriteInvalid"`
 	RewriteMissing   bool     `json:"rewriteMissing"`
 	Multilingual     bool     `json:"multilingual"`
 	Languages        []string `json:"languages"`
+	BaseURL          string   `json:"baseUrl"`
+	SiteTitle        string   `json:"siteTitle"`
+	FeedItemCount    int      `json:"feedItemCount"`
+	PostsPerPage     int      `json:"postsPerPage"`
+	FrontMatter      string   `json:"frontMatterFormat"`
+	PreviewSecret    string   `json:"previewSecret"`    // Signs preview URLs of drafts, disabled if empty
+	Permalink        string   `json:"permalink"`        // Pattern of post URLs, such as "/:year/:month/:slug/"
+	Highlighting     string   `json:"codeHighlighting"` // "client" with highlight.js or "server" when rendering posts
+	Watcher          string   `json:"watcher"`          // "notify" to be told of file changes by the OS, or "poll"
+	PollInterval     string   `json:"pollInterval"`     // How often files are listed when polling, like "2s"
 }
 
 func newDefaultConfig() *Config {
@@ -61,11 +83,21 @@ func newDefaultConfig() *Config {
 		PostPath:       filepath.Clean(DefaultPostPath),
 		PagePath:       filepath.Clean(DefaultPagePath),
 		AssetPath:      filepath.Clean(DefaultAssetPath),
+		BuildPath:      filepath.Clean(DefaultBuildPath),
 		PostFileExt:    DefaultPostFileExt,
 		RewriteInvalid: DefaultRewriteInvalid,
 		RewriteMissing: DefaultRewriteMissing,
 		Multilingual:   DefaultMultilingual,
 		Languages:      DefaultLanguages,
+		BaseURL:        DefaultBaseURL,
+		SiteTitle:      DefaultSiteTitle,
+		FeedItemCount:  DefaultFeedItemCount,
+		PostsPerPage:   DefaultPostsPerPage,
+		FrontMatter:    DefaultFrontMatter,
+		Permalink:      DefaultPermalink,
+		Highlighting:   DefaultHighlighting,
+		Watcher:        DefaultWatcher,
+		PollInterval:   DefaultPollInterval,
 	}
 }
 
@@ -83,10 +115,41 @@ func (cfg *Config) selfValidate() error {
 		return fmt.Errorf("invalid CPU count (%d)", cfg.MaxCPUs)
 	}
 
+	if cfg.FeedItemCount < 0 {
+		return fmt.Errorf("invalid feed item count (%d)", cfg.FeedItemCount)
+	}
+
+	if cfg.PostsPerPage < 0 {
+		return fmt.Errorf("invalid posts per page count (%d)", cfg.PostsPerPage)
+	}
+
+	if !validFrontMatterFormat(cfg.FrontMatter) {
+		return fmt.Errorf("invalid front matter format (%s)", cfg.FrontMatter)
+	}
+
+	if err := validPermalink(cfg.Permalink); err != nil {
+		return fmt.Errorf("invalid permalink pattern (%s), %v", cfg.Permalink, err)
+	}
+
+	if !validHighlighting(cfg.Highlighting) {
+		return fmt.Errorf("invalid code highlighting (%s), must be '%s' or '%s'",
+			cfg.Highlighting, clientHighlighting, serverHighlighting)
+	}
+
+	if !validWatcher(cfg.Watcher) {
+		return fmt.Errorf("invalid watcher (%s), must be '%s' or '%s'",
+			cfg.Watcher, notifyWatcher, pollWatcher)
+	}
+
+	if interval, err := time.ParseDuration(cfg.PollInterval); err != nil || interval <= 0 {
+		return fmt.Errorf("invalid poll interval (%s), must be a duration like '2s'", cfg.PollInterval)
+	}
+
 	if cfg.PostFileExt == "" {
 		return fmt.Errorf("invalid Post file extension (%s)", cfg.PostFileExt)
 	}
 	cfg.AssetPath = filepath.Clean(cfg.AssetPath)
+	cfg.BuildPath = filepath.Clean(cfg.BuildPath)
 	cfg.PostPath = filepath.Clean(cfg.PostPath)
 	cfg.TemplatePath = filepath.Clean(cfg.TemplatePath)
 
@@ -114,9 +177,11 @@ func loadFromFile() (*Config, error) {
 	}
 
 	jsonDec := json.NewDecoder(configRd)
-	config := Config{}
+	// Settings missing from the file, such as those added by newer
+	// versions of brog, keep their default value.
+	config := newDefaultConfig()
 
-	err = jsonDec.Decode(&config)
+	err = jsonDec.Decode(config)
 	if err != nil {
 		return nil, fmt.Errorf("decoding config file, %v", err)
 	}
@@ -127,10 +192,10 @@ func loadFromFile() (*Config, error) {
 	}
 
 	if err := configRd.Close(); err != nil {
-		return &config, fmt.Errorf("closing config file, %v", err)
+		return config, fmt.Errorf("closing config file, %v", err)
 	}
 
-	return &config, nil
+	return config, nil
 }
 
 func (cfg *Config) persistToFile(filename string) error {
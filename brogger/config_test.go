@@ -47,6 +47,18 @@ func TestSelfValidate(t *testing.T) {
 	if err == nil {
 		t.Error("Post file extension cannot be empty")
 	}
+	config.PostFileExt = DefaultPostFileExt
+	config.Watcher = "inotify"
+	err = config.selfValidate()
+	if err == nil {
+		t.Error("inotify is not a valid watcher")
+	}
+	config.Watcher = DefaultWatcher
+	config.PollInterval = "2"
+	err = config.selfValidate()
+	if err == nil {
+		t.Error("Poll interval must have a unit")
+	}
 }
 
 func TestJsonConfigStruct(t *testing.T) {
@@ -0,0 +1,200 @@
+package brogger
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aybabtme/log"
+)
+
+const (
+	// debounceDelay is how long files must be left alone before their
+	// events are handled, so that the temporary files and renames of an
+	// editor saving a file look like a single change.
+	debounceDelay = 100 * time.Millisecond
+	// maxDebounceDelay is the longest events wait while files keep changing.
+	maxDebounceDelay = time.Second
+	// Handling an event again, in case the file was only partly written.
+	retryDelay      = 250 * time.Millisecond
+	maxEventRetries = 3
+)
+
+// fileOp is what happened to a file, or to a directory.
+type fileOp int
+
+const (
+	fileCreated fileOp = iota
+	fileModified
+	fileRenamed
+	fileDeleted
+)
+
+func (op fileOp) String() string {
+	switch op {
+	case fileCreated:
+		return "create"
+	case fileModified:
+		return "modify"
+	case fileRenamed:
+		return "rename"
+	case fileDeleted:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// fileEvent is a change to a file, reported by a watcher.
+type fileEvent struct {
+	name string
+	op   fileOp
+}
+
+// eventHandler applies a file event. It's told when it's the last try to,
+// otherwise it can fail to be called again a bit later.
+type eventHandler func(ev fileEvent, lastTry bool) error
+
+// eventQueue coalesces the events of each file until things calm down, then
+// hands out a single event per file, telling what happened to it overall.
+// It isn't safe for concurrent use, it belongs to the goroutine receiving
+// the events of a watcher.
+type eventQueue struct {
+	handle  eventHandler
+	pending map[string]*pendingEvent // By filename
+	since   time.Time                // When the oldest pending event came
+	timer   *time.Timer              // Fires when the pending events are due
+}
+
+// pendingEvent is what happened to a file since its events were last
+// handled.
+type pendingEvent struct {
+	seq     int  // Order of the file's first event
+	created bool // The file was created by its first event
+	renamed bool // The file was last removed by a rename, rather than deleted
+	attempt int  // Times handling the event failed
+}
+
+func newEventQueue(handle eventHandler) *eventQueue {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	return &eventQueue{
+		handle:  handle,
+		pending: make(map[string]*pendingEvent),
+		timer:   timer,
+	}
+}
+
+// ready fires when the pending events are due, see flush.
+func (q *eventQueue) ready() <-chan time.Time {
+	return q.timer.C
+}
+
+// add coalesces `ev` with the other events of its file, and pushes back
+// handling them.
+func (q *eventQueue) add(ev fileEvent) {
+	now := time.Now()
+	if len(q.pending) == 0 {
+		q.since = now
+	}
+
+	pending, ok := q.pending[ev.name]
+	if !ok {
+		pending = &pendingEvent{seq: len(q.pending), created: ev.op == fileCreated}
+		q.pending[ev.name] = pending
+	}
+	// Changed again, so maybe not half written anymore
+	pending.attempt = 0
+	switch ev.op {
+	case fileRenamed:
+		pending.renamed = true
+	case fileDeleted:
+		pending.renamed = false
+	}
+
+	wait := debounceDelay
+	if deadline := q.since.Add(maxDebounceDelay); now.Add(wait).After(deadline) {
+		wait = deadline.Sub(now)
+	}
+	q.schedule(wait)
+}
+
+func (q *eventQueue) schedule(wait time.Duration) {
+	if !q.timer.Stop() {
+		select {
+		case <-q.timer.C:
+		default:
+		}
+	}
+	q.timer.Reset(wait)
+}
+
+// flush handles the pending events, once `ready` fired. What happened to a
+// file depends on whether it's still there: a file written and renamed in
+// place was modified, a temporary file created then removed is skipped.
+// Files gone are handled first, so that renamed files are known to be when
+// their new name shows up.
+func (q *eventQueue) flush() {
+	type resolved struct {
+		fileEvent
+		*pendingEvent
+	}
+	var events []resolved
+	for filename, pending := range q.pending {
+		ev := fileEvent{name: filename}
+		_, err := os.Stat(filename)
+		exists := err == nil
+		switch {
+		case exists && pending.created:
+			ev.op = fileCreated
+		case exists:
+			ev.op = fileModified
+		case pending.created:
+			// Came and went
+			continue
+		case pending.renamed:
+			ev.op = fileRenamed
+		default:
+			ev.op = fileDeleted
+		}
+		events = append(events, resolved{ev, pending})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		iGone := events[i].op == fileRenamed || events[i].op == fileDeleted
+		jGone := events[j].op == fileRenamed || events[j].op == fileDeleted
+		if iGone != jGone {
+			return iGone
+		}
+		return events[i].seq < events[j].seq
+	})
+	q.pending = make(map[string]*pendingEvent)
+
+	for _, ev := range events {
+		lastTry := ev.attempt == maxEventRetries
+		err := q.handle(ev.fileEvent, lastTry)
+		if err == nil {
+			continue
+		}
+
+		ll := log.Err(err).KV("file.name", ev.name).KV("file.event", ev.op.String())
+		if lastTry {
+			ll.Error("couldn't handle file event, giving up")
+			continue
+		}
+		ll.KV("file.attempt", ev.attempt+1).Info("couldn't handle file event, retrying")
+		if len(q.pending) == 0 {
+			q.since = time.Now()
+		}
+		ev.attempt++
+		ev.seq = len(q.pending)
+		q.pending[ev.name] = ev.pendingEvent
+	}
+	if len(q.pending) != 0 {
+		q.schedule(retryDelay)
+	}
+}
+
+// stop drops the pending events.
+func (q *eventQueue) stop() {
+	q.timer.Stop()
+	q.pending = make(map[string]*pendingEvent)
+}
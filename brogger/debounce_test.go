@@ -0,0 +1,139 @@
+package brogger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type recordedEvents struct {
+	events []fileEvent
+	fail   int // Fails this many times before handling events
+	last   []bool
+}
+
+func (r *recordedEvents) handle(ev fileEvent, lastTry bool) error {
+	r.last = append(r.last, lastTry)
+	if r.fail > 0 {
+		r.fail--
+		return fmt.Errorf("half written")
+	}
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func TestEventQueueCoalesces(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brog-events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	saved := filepath.Join(dir, "saved.md")
+	moved := filepath.Join(dir, "moved.md")
+	for _, filename := range []string{saved, moved} {
+		if err := ioutil.WriteFile(filename, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := &recordedEvents{}
+	q := newEventQueue(rec.handle)
+	// Saved by an editor through a temporary file
+	q.add(fileEvent{name: filepath.Join(dir, "saved.md.tmp"), op: fileCreated})
+	q.add(fileEvent{name: saved, op: fileRenamed})
+	q.add(fileEvent{name: saved, op: fileCreated})
+	q.add(fileEvent{name: saved, op: fileModified})
+	q.add(fileEvent{name: filepath.Join(dir, "saved.md.tmp"), op: fileRenamed})
+	// Renamed, the new name coming first
+	q.add(fileEvent{name: moved, op: fileCreated})
+	q.add(fileEvent{name: filepath.Join(dir, "old.md"), op: fileRenamed})
+	// Gone
+	q.add(fileEvent{name: filepath.Join(dir, "deleted.md"), op: fileModified})
+	q.add(fileEvent{name: filepath.Join(dir, "deleted.md"), op: fileDeleted})
+
+	select {
+	case <-q.ready():
+	case <-time.After(maxDebounceDelay + time.Second):
+		t.Fatal("Pending events never became ready")
+	}
+	q.flush()
+
+	want := []fileEvent{
+		{name: filepath.Join(dir, "old.md"), op: fileRenamed},
+		{name: filepath.Join(dir, "deleted.md"), op: fileDeleted},
+		{name: saved, op: fileModified},
+		{name: moved, op: fileCreated},
+	}
+	if len(rec.events) != len(want) {
+		t.Fatalf("Expected %d events, got %v", len(want), rec.events)
+	}
+	for i := range want {
+		if rec.events[i] != want[i] {
+			t.Errorf("Event %d is %v, expected %v", i, rec.events[i], want[i])
+		}
+	}
+}
+
+func TestEventQueueRetries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brog-events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "partial.md")
+	if err := ioutil.WriteFile(filename, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := &recordedEvents{fail: maxEventRetries + 1}
+	q := newEventQueue(rec.handle)
+	q.add(fileEvent{name: filename, op: fileCreated})
+	for i := 0; i <= maxEventRetries; i++ {
+		q.flush()
+	}
+	if len(q.pending) != 0 {
+		t.Error("Event still pending after its last try")
+	}
+	if len(rec.last) != maxEventRetries+1 || !rec.last[maxEventRetries] || rec.last[0] {
+		t.Errorf("Last try isn't told apart from the others. Got %v", rec.last)
+	}
+
+	// Succeeds once written
+	rec = &recordedEvents{fail: 1}
+	q = newEventQueue(rec.handle)
+	q.add(fileEvent{name: filename, op: fileCreated})
+	q.flush()
+	q.flush()
+	if len(rec.events) != 1 || rec.events[0].op != fileCreated {
+		t.Errorf("Event wasn't retried. Got %v", rec.events)
+	}
+}
+
+func TestModifiedPostKeptUntilValid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brog-modify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "hello.md")
+	if err := ioutil.WriteFile(filename, []byte("{\"title\": \"Hello\"}\nHello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pmgr, err := newPostManager(SetUpDefaultBrog(), dir, DefaultPermalink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filename, []byte("{\"title\": \"Hel"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := pmgr.processPostEvent(fileEvent{name: filename, op: fileModified}, false); err == nil {
+		t.Error("Half written post loaded")
+	}
+	if p, ok := pmgr.GetPost("hello"); !ok || p.Title != "Hello" {
+		t.Error("Previous version of the post is gone while the new one doesn't load")
+	}
+}
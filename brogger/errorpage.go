@@ -0,0 +1,107 @@
+package brogger
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"text/template"
+
+	"github.com/aybabtme/log"
+)
+
+// render executes the template `doWith` gives with `data`. The page is only
+// written once it rendered entirely, so that a failure is answered with the
+// 500 template rather than half a page. The error is returned for the
+// caller to log.
+func (b *Brog) render(rw http.ResponseWriter, req *http.Request, doWith func(func(*template.Template)), data appContent) error {
+	err := b.renderStatus(rw, http.StatusOK, doWith, data)
+	if err != nil {
+		b.serverError(rw, req, err)
+	}
+	return err
+}
+
+func (b *Brog) renderStatus(rw http.ResponseWriter, status int, doWith func(func(*template.Template)), data appContent) error {
+	buf := bytes.NewBuffer(nil)
+
+	var err error
+	doWith(func(t *template.Template) {
+		err = t.Execute(buf, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	rw.WriteHeader(status)
+	// The client is gone if it fails, nothing to tell it
+	_, _ = buf.WriteTo(rw)
+	return nil
+}
+
+// notFound renders the 404 template, for URLs that lead nowhere.
+func (b *Brog) notFound(rw http.ResponseWriter, req *http.Request) {
+	if b.tmplMngr == nil {
+		// No templates loaded to render it with
+		http.NotFound(rw, req)
+		return
+	}
+	lang, _ := b.extractLanguage(req)
+	data := b.baseContent(lang)
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := b.renderStatus(rw, http.StatusNotFound, b.tmplMngr.DoWithNotFound, data)
+	if err != nil {
+		log.Err(err).KV("req.uri", req.RequestURI).Error("couldn't render not found template")
+		http.NotFound(rw, req)
+	}
+}
+
+// serverError renders the 500 template, after `err` kept a page from being
+// served. The details of `err` are shown in development only, they can tell
+// visitors too much about the server. Logging them is up to the caller.
+func (b *Brog) serverError(rw http.ResponseWriter, req *http.Request, err error) {
+	detail := http.StatusText(http.StatusInternalServerError)
+	if !b.isProd {
+		detail = err.Error()
+	}
+	if b.tmplMngr == nil {
+		// No templates loaded to render it with
+		http.Error(rw, detail, http.StatusInternalServerError)
+		return
+	}
+
+	lang, _ := b.extractLanguage(req)
+	data := b.baseContent(lang)
+	if !b.isProd {
+		data.Error = detail
+	}
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmplErr := b.renderStatus(rw, http.StatusInternalServerError, b.tmplMngr.DoWithError, data)
+	if tmplErr != nil {
+		log.Err(tmplErr).KV("req.uri", req.RequestURI).Error("couldn't render error template")
+		http.Error(rw, detail, http.StatusInternalServerError)
+	}
+}
+
+// recoverHandlerFunc answers with the 500 template when `h` panics, rather
+// than with a dropped connection.
+func (b *Brog) recoverHandlerFunc(h http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if r == http.ErrAbortHandler {
+				// Meant to abort the response, not an error
+				panic(r)
+			}
+			err := fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+			log.Err(err).KV("req.uri", req.RequestURI).Error("recovered from panic serving request")
+			b.serverError(rw, req, err)
+		}()
+		h.ServeHTTP(rw, req)
+	})
+}
@@ -0,0 +1,113 @@
+package brogger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func SetUpBrogWithTemplates(t *testing.T, isProd bool) *Brog {
+	b := SetUpDefaultBrog()
+	b.isProd = isProd
+	var err error
+	if b.tmplMngr, err = newTemplateManager(b, b.Config.TemplatePath); err != nil {
+		t.Fatal(err)
+	}
+	if b.postMngr, err = newPostManager(b, b.Config.PostPath, b.Config.Permalink); err != nil {
+		t.Fatal(err)
+	}
+	if b.pageMngr, err = newPostManager(b, b.Config.PagePath, pagePermalink); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestNotFoundPage(t *testing.T) {
+	_ = os.Chdir("base")
+	defer func() { _ = os.Chdir("..") }()
+	b := SetUpBrogWithTemplates(t, true)
+
+	rec := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/pages/nowhere", nil)
+	req.RequestURI = "/pages/nowhere"
+	b.pageFunc(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "Page not found") || !strings.Contains(body, "<html>") {
+		t.Errorf("Not found page isn't rendered with the application template, got %s", body)
+	}
+}
+
+func TestUnknownPathNotFound(t *testing.T) {
+	_ = os.Chdir("base")
+	defer func() { _ = os.Chdir("..") }()
+	b := SetUpBrogWithTemplates(t, true)
+
+	for uri, want := range map[string]int{
+		"/":                 http.StatusOK,
+		"/?en":              http.StatusOK,
+		"/does-not-exist":   http.StatusNotFound,
+		"/2014/06/nowhere/": http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", uri, nil)
+		req.RequestURI = uri
+		b.indexFunc(rec, req)
+		if rec.Code != want {
+			t.Errorf("'%s' answered %d, expected %d", uri, rec.Code, want)
+		}
+	}
+}
+
+func TestServerErrorPage(t *testing.T) {
+	_ = os.Chdir("base")
+	defer func() { _ = os.Chdir("..") }()
+
+	for _, isProd := range []bool{true, false} {
+		b := SetUpBrogWithTemplates(t, isProd)
+		// Fails half way through
+		b.tmplMngr.post = template.Must(template.New("post").Parse("half a page{{.Missing}}"))
+
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/", nil)
+		if err := b.render(rec, req, b.tmplMngr.DoWithPost, b.baseContent("")); err == nil {
+			t.Fatal("Expected the rendering to fail")
+		}
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+		}
+		body := rec.Body.String()
+		if strings.Contains(body, "half a page") {
+			t.Error("Page that failed to render was partly written")
+		}
+		if !strings.Contains(body, "Something went wrong") {
+			t.Errorf("Error page isn't rendered, got %s", body)
+		}
+		if showsDetail := strings.Contains(body, "Missing"); showsDetail == isProd {
+			t.Errorf("In production %v, error detail shown %v", isProd, showsDetail)
+		}
+	}
+}
+
+func TestRecoverHandler(t *testing.T) {
+	_ = os.Chdir("base")
+	defer func() { _ = os.Chdir("..") }()
+	b := SetUpBrogWithTemplates(t, true)
+
+	h := b.recoverHandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		panic("secret/path/to/server")
+	})
+	rec := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	h(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+	if body := rec.Body.String(); strings.Contains(body, "secret") || !strings.Contains(body, "Something went wrong") {
+		t.Errorf("Expected the error page without detail, got %s", body)
+	}
+}
@@ -0,0 +1,228 @@
+package brogger
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aybabtme/log"
+)
+
+const (
+	atomExt = ".atom"
+	rssExt  = ".rss"
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Base    string      `xml:"xml:base,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Links   []atomLink  `xml:"link"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title   string     `xml:"title"`
+	ID      string     `xml:"id"`
+	Link    atomLink   `xml:"link"`
+	Updated string     `xml:"updated"`
+	Author  atomAuthor `xml:"author"`
+	Summary *atomText  `xml:"summary,omitempty"`
+	Content atomText   `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Creator     string `xml:"dc:creator,omitempty"`
+	Description string `xml:"description"`
+	Content     string `xml:"content:encoded"`
+}
+
+// feedPath is the URL of the feed with extension `ext` for `lang`. The feed
+// for all languages lives at `/feed.<ext>`.
+func feedPath(lang, ext string) string {
+	if lang == "" {
+		return "/feed" + ext
+	}
+	return "/feed." + lang + ext
+}
+
+// absURL prefixes `urlpath` with the base URL of the brog.
+func (b *Brog) absURL(urlpath string) string {
+	return strings.TrimSuffix(b.Config.BaseURL, "/") + urlpath
+}
+
+// feedContent is the content of `p` for feeds, whose readers show it away
+// from the brog: its links and images point to absolute URLs.
+func (b *Brog) feedContent(p *post) string {
+	return resolveURLs(p.Content, b.absURL(p.Permalink()), func(string) bool { return false })
+}
+
+// feedPosts are the most recent posts to syndicate for `lang`.
+func (b *Brog) feedPosts(lang string) []*post {
+	posts := b.postMngr.GetAllPostsWithLanguage(lang)
+	if count := b.Config.FeedItemCount; count > 0 && len(posts) > count {
+		posts = posts[:count]
+	}
+	return posts
+}
+
+// feedUpdated is the date of the most recent post, or now if there are none.
+func feedUpdated(posts []*post) time.Time {
+	if len(posts) == 0 {
+		return time.Now()
+	}
+	return posts[0].Date
+}
+
+func (b *Brog) renderAtom(w io.Writer, lang string) error {
+	posts := b.feedPosts(lang)
+
+	feed := atomFeed{
+		Base:  b.absURL("/"),
+		Title: b.Config.SiteTitle,
+		ID:    b.absURL("/"),
+		Links: []atomLink{
+			{Href: b.absURL("/")},
+			{Href: b.absURL(feedPath(lang, atomExt)), Rel: "self"},
+		},
+		Updated: feedUpdated(posts).Format(time.RFC3339),
+	}
+
+	for _, post := range posts {
+		link := b.absURL(post.Permalink())
+		entry := atomEntry{
+			Title:   post.Title,
+			ID:      link,
+			Link:    atomLink{Href: link},
+			Updated: post.Date.Format(time.RFC3339),
+			Author:  atomAuthor{Name: post.Author},
+			Content: atomText{Type: "html", Body: b.feedContent(post)},
+		}
+		if post.Abstract != "" {
+			entry.Summary = &atomText{Type: "text", Body: post.Abstract}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return writeXML(w, feed)
+}
+
+func (b *Brog) renderRSS(w io.Writer, lang string) error {
+	posts := b.feedPosts(lang)
+
+	feed := rssFeed{
+		Version:   "2.0",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		Channel: rssChannel{
+			Title:         b.Config.SiteTitle,
+			Link:          b.absURL("/"),
+			Description:   b.Config.SiteTitle,
+			Language:      lang,
+			LastBuildDate: feedUpdated(posts).Format(time.RFC1123Z),
+		},
+	}
+
+	for _, post := range posts {
+		link := b.absURL(post.Permalink())
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       post.Title,
+			Link:        link,
+			GUID:        link,
+			PubDate:     post.Date.Format(time.RFC1123Z),
+			Creator:     post.Author,
+			Description: post.Abstract,
+			Content:     b.feedContent(post),
+		})
+	}
+
+	return writeXML(w, feed)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("writing XML header, %v", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encoding XML, %v", err)
+	}
+	return nil
+}
+
+// feedLanguages are the languages for which a feed is published, the empty
+// language being the feed of all posts.
+func (b *Brog) feedLanguages() []string {
+	langs := []string{""}
+	if b.Config.Multilingual {
+		langs = append(langs, b.Config.Languages...)
+	}
+	return langs
+}
+
+// handleFeeds registers the Atom and RSS feeds of every feed language.
+func (b *Brog) handleFeeds() {
+	for _, lang := range b.feedLanguages() {
+		atomFunc := b.feedFunc(lang, "application/atom+xml", b.renderAtom)
+		rssFunc := b.feedFunc(lang, "application/rss+xml", b.renderRSS)
+		b.HandleFunc(feedPath(lang, atomExt), b.prometheusHandler(atomFunc, "srv", "atom"))
+		b.HandleFunc(feedPath(lang, rssExt), b.prometheusHandler(rssFunc, "srv", "rss"))
+	}
+}
+
+func (b *Brog) feedFunc(lang, contentType string, render func(io.Writer, string) error) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		// Rendered entirely first, so that a failure isn't sent after half
+		// a feed
+		buf := bytes.NewBuffer(nil)
+		if err := render(buf, lang); err != nil {
+			log.Err(err).KV("feed.lang", lang).Error("couldn't render feed")
+			b.serverError(rw, req, err)
+			return
+		}
+		rw.Header().Set("Content-Type", contentType+"; charset=utf-8")
+		_, _ = buf.WriteTo(rw)
+	}
+}
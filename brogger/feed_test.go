@@ -0,0 +1,104 @@
+package brogger
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func SetUpFeedBrog() *Brog {
+	b := SetUpDefaultBrog()
+	b.Config.BaseURL = "http://example.com/"
+	b.postMngr = &postManager{
+		sortedPosts: []*post{
+			{id: "second", permalink: "/posts/second", Title: "Second", Author: "Brog", Language: "fr", Date: time.Date(2014, 1, 2, 0, 0, 0, 0, time.UTC), Content: `<p>deux <a href="/posts/first">un</a> <img src="/posts/second/photo.jpg"> <a href="#fin">fin</a> <a href="http://golang.org/">go</a></p>`},
+			{id: "first", permalink: "/posts/first", Title: "First", Author: "Brog", Language: "en", Date: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC), Content: "<p>one</p>"},
+		},
+	}
+	return b
+}
+
+func TestRenderAtom(t *testing.T) {
+	b := SetUpFeedBrog()
+	buf := bytes.NewBuffer(nil)
+	if err := b.renderAtom(buf, ""); err != nil {
+		t.Fatalf("Can't render Atom feed: %v", err)
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(buf.Bytes(), &feed); err != nil {
+		t.Fatalf("Atom feed isn't valid XML: %v", err)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(feed.Entries))
+	}
+	if feed.Entries[0].ID != "http://example.com/posts/second" {
+		t.Error("Entry ID is not an absolute link. Got", feed.Entries[0].ID)
+	}
+	if feed.Updated != "2014-01-02T00:00:00Z" {
+		t.Error("Feed isn't updated at the date of the most recent post. Got", feed.Updated)
+	}
+	if !strings.Contains(buf.String(), `xml:base="http://example.com/"`) {
+		t.Error("Atom feed has no base URL")
+	}
+	for _, want := range []string{
+		`href="http://example.com/posts/first"`,
+		`src="http://example.com/posts/second/photo.jpg"`,
+		`href="http://example.com/posts/second#fin"`,
+		`href="http://golang.org/"`,
+	} {
+		if !strings.Contains(feed.Entries[0].Content.Body, want) {
+			t.Errorf("Entry content doesn't have %s. Got %s", want, feed.Entries[0].Content.Body)
+		}
+	}
+}
+
+func TestRenderRSSWithLanguage(t *testing.T) {
+	b := SetUpFeedBrog()
+	buf := bytes.NewBuffer(nil)
+	if err := b.renderRSS(buf, "en"); err != nil {
+		t.Fatalf("Can't render RSS feed: %v", err)
+	}
+	var feed rssFeed
+	if err := xml.Unmarshal(buf.Bytes(), &feed); err != nil {
+		t.Fatalf("RSS feed isn't valid XML: %v", err)
+	}
+	if len(feed.Channel.Items) != 1 || feed.Channel.Items[0].Title != "First" {
+		t.Error("RSS feed doesn't filter posts by language")
+	}
+}
+
+func TestFeedItemCount(t *testing.T) {
+	b := SetUpFeedBrog()
+	b.Config.FeedItemCount = 1
+	if posts := b.feedPosts(""); len(posts) != 1 || posts[0].Title != "Second" {
+		t.Error("Feed doesn't keep only the most recent posts")
+	}
+}
+
+func TestFeedFailureNotSentHalfWritten(t *testing.T) {
+	b := SetUpDefaultBrog()
+	b.isProd = true
+	h := b.feedFunc("", "application/atom+xml", func(w io.Writer, lang string) error {
+		_, _ = w.Write([]byte("<feed>half a feed"))
+		return fmt.Errorf("reading /secret/path")
+	})
+
+	rec := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/feed.atom", nil)
+	h(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+	if body := rec.Body.String(); strings.Contains(body, "half a feed") || strings.Contains(body, "secret") {
+		t.Errorf("Failed feed was sent, got %s", body)
+	}
+	if ct := rec.Header().Get("Content-Type"); strings.Contains(ct, "atom") {
+		t.Errorf("Error is sent as a feed, with content type %s", ct)
+	}
+}
@@ -0,0 +1,288 @@
+package brogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Formats of the header that holds the metadata of a post, before its
+// Markdown content.
+const (
+	jsonFrontMatter = "json"
+	yamlFrontMatter = "yaml"
+	tomlFrontMatter = "toml"
+)
+
+// Lines delimiting a front matter, Jekyll and Hugo style.
+const (
+	yamlDelimiter = "---"
+	tomlDelimiter = "+++"
+)
+
+// dateLayouts are the layouts tried on dates written as strings in a YAML or
+// TOML front matter.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// postTimeFields are the JSON names of the fields of a post holding a date.
+var postTimeFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	typ := reflect.TypeOf(post{})
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Type != reflect.TypeOf(time.Time{}) && field.Type != reflect.TypeOf(&time.Time{}) {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+func validFrontMatterFormat(format string) bool {
+	switch format {
+	case jsonFrontMatter, yamlFrontMatter, tomlFrontMatter:
+		return true
+	}
+	return false
+}
+
+// readFrontMatter decodes the front matter at the beginning of `data` into
+// `p`, remembering its format, and returns the Markdown that follows it.
+func readFrontMatter(data []byte, p *post) ([]byte, error) {
+	firstLine, _ := splitLine(data)
+
+	var decode func([]byte) (map[string]interface{}, error)
+	switch firstLine {
+	case yamlDelimiter:
+		p.format = yamlFrontMatter
+		decode = func(header []byte) (map[string]interface{}, error) {
+			fields := make(map[string]interface{})
+			err := yaml.Unmarshal(header, &fields)
+			return fields, err
+		}
+	case tomlDelimiter:
+		p.format = tomlFrontMatter
+		decode = func(header []byte) (map[string]interface{}, error) {
+			fields := make(map[string]interface{})
+			_, err := toml.Decode(string(header), &fields)
+			return fields, err
+		}
+	default:
+		p.format = jsonFrontMatter
+		return readJSONFrontMatter(data, p)
+	}
+
+	header, markdown, err := splitDelimited(data, firstLine)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := decode(header)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s header, %v", p.format, err)
+	}
+	if err := fieldsToPost(fields, p); err != nil {
+		return nil, fmt.Errorf("reading %s header, %v", p.format, err)
+	}
+	return markdown, nil
+}
+
+func readJSONFrontMatter(data []byte, p *post) ([]byte, error) {
+	rd := bytes.NewReader(data)
+	dec := json.NewDecoder(rd)
+	if err := dec.Decode(p); err != nil {
+		return nil, fmt.Errorf("reading json header, %v", err)
+	}
+
+	// Read content after that JSON header.  json.Decoder buffers some data, so
+	// we need to read its buffer before what is left in the original reader.
+	markdown, err := ioutil.ReadAll(io.MultiReader(dec.Buffered(), rd))
+	if err != nil {
+		return nil, fmt.Errorf("reading markdown after json header, %v", err)
+	}
+	return markdown, nil
+}
+
+// splitLine returns the first line of `data`, without its line ending, and
+// what follows it.
+func splitLine(data []byte) (string, []byte) {
+	i := bytes.IndexByte(data, '\n')
+	if i < 0 {
+		return strings.TrimSpace(string(data)), nil
+	}
+	return strings.TrimSpace(string(data[:i])), data[i+1:]
+}
+
+// splitDelimited returns what lies between the first line of `data` and the
+// next line equal to `delim`, and what follows that line.
+func splitDelimited(data []byte, delim string) ([]byte, []byte, error) {
+	_, rest := splitLine(data)
+	header := rest
+	for len(rest) != 0 {
+		line, next := splitLine(rest)
+		if line == delim {
+			return header[:len(header)-len(rest)], next, nil
+		}
+		rest = next
+	}
+	return nil, nil, fmt.Errorf("missing closing '%s' of front matter", delim)
+}
+
+// fieldsToPost sets the fields of `p` from those decoded out of a YAML or
+// TOML front matter, going through JSON so that posts have a single set of
+// field names whatever their format.
+func fieldsToPost(fields map[string]interface{}, p *post) error {
+	for name, val := range fields {
+		val = jsonCompatible(val)
+		if str, ok := val.(string); ok && postTimeFields[name] {
+			date, err := parseDate(str)
+			if err != nil {
+				return fmt.Errorf("field '%s', %v", name, err)
+			}
+			val = date
+		}
+		fields[name] = val
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, p)
+}
+
+// jsonCompatible converts the maps decoded by YAML, which can have keys of
+// any type, to maps that can be encoded to JSON.
+func jsonCompatible(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			m[fmt.Sprint(key)] = jsonCompatible(elem)
+		}
+		return m
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = jsonCompatible(elem)
+		}
+		return v
+	}
+	return val
+}
+
+func parseDate(str string) (time.Time, error) {
+	str = strings.TrimSpace(str)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, str); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown date format '%s'", str)
+}
+
+// writeFrontMatter encodes the metadata of `p` as a front matter in its
+// format, JSON by default.
+func writeFrontMatter(w io.Writer, p *post) error {
+	if p.format == "" || p.format == jsonFrontMatter {
+		data, err := json.MarshalIndent(p, "", strings.Repeat(" ", JSONIndentCount))
+		if err != nil {
+			return fmt.Errorf("encoding post to JSON, %v", err)
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	}
+
+	fields, err := postToFields(p)
+	if err != nil {
+		return fmt.Errorf("listing fields of post, %v", err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	var delim string
+	switch p.format {
+	case yamlFrontMatter:
+		delim = yamlDelimiter
+		data, err := yaml.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("encoding post to YAML, %v", err)
+		}
+		_, _ = buf.Write(data)
+	case tomlFrontMatter:
+		delim = tomlDelimiter
+		// Encoding one field at a time keeps them in the order of the post
+		enc := toml.NewEncoder(buf)
+		for _, field := range fields {
+			if err := enc.Encode(map[string]interface{}{field.Key.(string): field.Value}); err != nil {
+				return fmt.Errorf("encoding post to TOML, %v", err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown front matter format '%s'", p.format)
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n%s%s\n", delim, buf.Bytes(), delim)
+	return err
+}
+
+// postToFields lists the fields of `p` as JSON would encode them, in order,
+// with dates as time.Time.
+func postToFields(p *post) (yaml.MapSlice, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// Opening brace
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var fields yaml.MapSlice
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name := tok.(string)
+
+		var val interface{}
+		if err := dec.Decode(&val); err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case string:
+			if postTimeFields[name] {
+				if val, err = parseDate(v); err != nil {
+					return nil, err
+				}
+			}
+		case float64:
+			// Keep integers as integers, TOML tells them apart
+			if v == float64(int64(v)) {
+				val = int64(v)
+			}
+		}
+		fields = append(fields, yaml.MapItem{Key: name, Value: val})
+	}
+	return fields, nil
+}
@@ -0,0 +1,110 @@
+package brogger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadYAMLFrontMatter(t *testing.T) {
+	data := []byte(`---
+title: "My: YAML post"
+date: 2013-10-30 23:59:59 -0400
+author: Antoine Grondin
+tags: [brog, yaml]
+layout: post
+---
+# Hello
+`)
+	var p post
+	markdown, err := readFrontMatter(data, &p)
+	if err != nil {
+		t.Fatalf("Can't read YAML front matter: %v", err)
+	}
+	if p.format != yamlFrontMatter {
+		t.Error("Front matter not detected as YAML. Got", p.format)
+	}
+	if p.Title != "My: YAML post" || p.Author != "Antoine Grondin" {
+		t.Errorf("Wrong title or author: %q, %q", p.Title, p.Author)
+	}
+	if want := time.Date(2013, 10, 31, 3, 59, 59, 0, time.UTC); !p.Date.Equal(want) {
+		t.Error("Jekyll style date not parsed properly. Got", p.Date)
+	}
+	if len(p.Tags) != 2 || p.Tags[1] != "yaml" {
+		t.Error("Tags not read properly. Got", p.Tags)
+	}
+	if string(markdown) != "# Hello\n" {
+		t.Errorf("Wrong markdown after front matter: %q", markdown)
+	}
+}
+
+func TestReadTOMLFrontMatter(t *testing.T) {
+	data := []byte(`+++
+title = "My TOML post"
+date = 2013-10-30T23:59:59Z
+invisible = true
++++
+# Hello
+`)
+	var p post
+	markdown, err := readFrontMatter(data, &p)
+	if err != nil {
+		t.Fatalf("Can't read TOML front matter: %v", err)
+	}
+	if p.format != tomlFrontMatter || p.Title != "My TOML post" || !p.Invisible {
+		t.Errorf("TOML front matter not read properly: %+v", p)
+	}
+	if want := time.Date(2013, 10, 30, 23, 59, 59, 0, time.UTC); !p.Date.Equal(want) {
+		t.Error("TOML date not parsed properly. Got", p.Date)
+	}
+	if string(markdown) != "# Hello\n" {
+		t.Errorf("Wrong markdown after front matter: %q", markdown)
+	}
+}
+
+func TestUnclosedFrontMatter(t *testing.T) {
+	var p post
+	if _, err := readFrontMatter([]byte("---\ntitle: oops\n# Hello\n"), &p); err == nil {
+		t.Error("Unclosed front matter should not be accepted")
+	}
+}
+
+func TestExportKeepsFrontMatterFormat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brog_frontmatter")
+	if err != nil {
+		t.Fatalf("Can't create directory: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	for _, format := range []string{jsonFrontMatter, yamlFrontMatter, tomlFrontMatter} {
+		orig := post{
+			Title:    "Exported",
+			Date:     time.Date(2014, 2, 3, 4, 5, 6, 0, time.UTC),
+			Author:   "Brog",
+			Language: "en",
+			Tags:     []string{"a", "b"},
+			Content:  "Some *markdown*.\n",
+			format:   format,
+		}
+		filename := filepath.Join(dir, format+".md")
+		if err := orig.exportToFile(filename); err != nil {
+			t.Fatalf("Can't export %s post: %v", format, err)
+		}
+
+		got, err := newPostFromFile(filename, false)
+		if err != nil {
+			t.Fatalf("Can't read back %s post: %v", format, err)
+		}
+		if got.format != format {
+			t.Errorf("Exported %s post read back as %s", format, got.format)
+		}
+		if got.Title != orig.Title || !got.Date.Equal(orig.Date) || len(got.Tags) != 2 {
+			t.Errorf("Exported %s post doesn't read back the same: %+v", format, got)
+		}
+		if got.Content != "<p>Some <em>markdown</em>.</p>\n" {
+			t.Errorf("Markdown of exported %s post changed: %q", format, got.Content)
+		}
+	}
+}
@@ -1,15 +1,144 @@
 package brogger
 
 import (
-	"io"
+	"compress/gzip"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
+// gzipResponseWriter compresses the body of the response, unless it has
+// none or it's already compressed, like images are.
 type gzipResponseWriter struct {
-	io.Writer
 	http.ResponseWriter
+	gz          *gzip.Writer // Set once the response is known to be compressed
+	status      int          // Status of the response, 0 until it's written
+	wroteHeader bool         // Whether the header was sent, after `status`
 }
 
-func (w gzipResponseWriter) Write(b []byte) (int, error) {
-	return w.Writer.Write(b)
+func newGzipResponseWriter(rw http.ResponseWriter) *gzipResponseWriter {
+	return &gzipResponseWriter{ResponseWriter: rw}
+}
+
+// WriteHeader sends the header once the content type is known, which can
+// take until the body is written.
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+	if w.Header().Get("Content-Type") != "" || !hasBody(code) {
+		w.writeHeader()
+	}
+}
+
+func (w *gzipResponseWriter) writeHeader() {
+	w.wroteHeader = true
+	h := w.Header()
+	if hasBody(w.status) && h.Get("Content-Encoding") == "" && compressible(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.writeHeader()
+	}
+	if w.gz == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.gz.Write(b)
+}
+
+// Close writes the end of the compressed body, if it was compressed.
+func (w *gzipResponseWriter) Close() error {
+	if w.status != 0 && !w.wroteHeader {
+		// Nothing was written after the status
+		w.writeHeader()
+	}
+	if w.gz == nil {
+		return nil
+	}
+	return w.gz.Close()
+}
+
+// hasBody tells if responses with status `code` have a body worth
+// compressing.
+func hasBody(code int) bool {
+	return code >= http.StatusOK &&
+		code != http.StatusNoContent &&
+		code != http.StatusPartialContent &&
+		code != http.StatusNotModified
+}
+
+// compressible tells if content of type `contentType` is made smaller by
+// gzip. Images, fonts and archives are compressed already.
+func compressible(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case strings.HasSuffix(mediaType, "+xml"), strings.HasSuffix(mediaType, "+json"):
+		return true
+	}
+	switch mediaType {
+	case "application/javascript", "application/x-javascript", "application/json", "application/xml":
+		return true
+	}
+	return false
+}
+
+// acceptsGzip tells if a client sending the `Accept-Encoding` header
+// `accept` can read gzipped responses.
+func acceptsGzip(accept string) bool {
+	gzipQ, anyQ := -1.0, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			if val, err := strconv.ParseFloat(param[len("q="):], 64); err == nil {
+				q = val
+			}
+		}
+		switch strings.ToLower(strings.TrimSpace(params[0])) {
+		case "gzip", "x-gzip":
+			gzipQ = q
+		case "*":
+			anyQ = q
+		}
+	}
+	if gzipQ >= 0 {
+		return gzipQ > 0
+	}
+	return anyQ > 0
+}
+
+// gzipHandler compresses the responses of `h` for the clients that accept
+// it.
+func (b *Brog) gzipHandler(h http.Handler) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(req.Header.Get("Accept-Encoding")) {
+			h.ServeHTTP(w, req)
+			return
+		}
+		gzrw := newGzipResponseWriter(w)
+		defer func() {
+			_ = gzrw.Close()
+		}()
+		h.ServeHTTP(gzrw, req)
+	})
 }
@@ -0,0 +1,92 @@
+package brogger
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	for accept, want := range map[string]bool{
+		"":                      false,
+		"gzip":                  true,
+		"deflate, gzip;q=0.8":   true,
+		"gzip;q=0":              false,
+		"br, *":                 true,
+		"*;q=0.5, gzip;q=0":     false,
+		"identity":              false,
+		"GZIP ; q=1.0, deflate": true,
+	} {
+		if got := acceptsGzip(accept); got != want {
+			t.Errorf("Accept-Encoding '%s' accepts gzip: %v, expected %v", accept, got, want)
+		}
+	}
+}
+
+func TestGzipHandler(t *testing.T) {
+	b := SetUpDefaultBrog()
+	body := "<html><body>Hello, hello, hello</body></html>"
+	h := b.gzipHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/photo.png" {
+			rw.Header().Set("Content-Type", "image/png")
+		}
+		_, _ = rw.Write([]byte(body))
+	}))
+
+	req, _ := http.NewRequest("GET", "/index.html", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatal("HTML wasn't compressed")
+	}
+	if rec.Header().Get("Vary") != "Accept-Encoding" {
+		t.Error("Compressed response doesn't vary by Accept-Encoding")
+	}
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ioutil.ReadAll(gz); string(got) != body {
+		t.Errorf("Expected '%s' once decompressed, got '%s'", body, got)
+	}
+
+	req, _ = http.NewRequest("GET", "/photo.png", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec = httptest.NewRecorder()
+	h(rec, req)
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != body {
+		t.Error("Image was compressed again")
+	}
+
+	req, _ = http.NewRequest("GET", "/index.html", nil)
+	rec = httptest.NewRecorder()
+	h(rec, req)
+	if rec.Header().Get("Content-Encoding") != "" || rec.Header().Get("Vary") != "Accept-Encoding" {
+		t.Error("Response compressed for a client not accepting it, or not varying by Accept-Encoding")
+	}
+}
+
+func TestGzipSniffsContentType(t *testing.T) {
+	// Like handlers wrapped to count statuses, writing the header first
+	rec := httptest.NewRecorder()
+	gzrw := newGzipResponseWriter(rec)
+	gzrw.WriteHeader(http.StatusOK)
+	_, _ = gzrw.Write([]byte("<!doctype html><html></html>"))
+	if err := gzrw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Error("HTML whose header was written before its type was known wasn't compressed")
+	}
+
+	rec = httptest.NewRecorder()
+	gzrw = newGzipResponseWriter(rec)
+	gzrw.WriteHeader(http.StatusFound)
+	_ = gzrw.Close()
+	if rec.Code != http.StatusFound {
+		t.Error("Status without a body wasn't sent. Got", rec.Code)
+	}
+}
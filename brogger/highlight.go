@@ -0,0 +1,103 @@
+package brogger
+
+import (
+	"bytes"
+	"html"
+	"strings"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/russross/blackfriday"
+)
+
+// Where code blocks are highlighted.
+const (
+	// By highlight.js, in the browser of the reader.
+	clientHighlighting = "client"
+	// When rendering posts, so that feeds and readers without JavaScript get
+	// highlighted code too.
+	serverHighlighting = "server"
+)
+
+func validHighlighting(mode string) bool {
+	return mode == clientHighlighting || mode == serverHighlighting
+}
+
+// highlightClasses are the classes highlight.js gives to tokens, which the
+// stylesheets of brog, like github.css, color.
+var highlightClasses = map[chroma.TokenType]string{
+	chroma.Comment:             "comment",
+	chroma.CommentPreproc:      "preprocessor",
+	chroma.CommentPreprocFile:  "string",
+	chroma.Keyword:             "keyword",
+	chroma.KeywordType:         "built_in",
+	chroma.Name:                "",
+	chroma.NameAttribute:       "attribute",
+	chroma.NameBuiltin:         "built_in",
+	chroma.NameBuiltinPseudo:   "built_in",
+	chroma.NameClass:           "class",
+	chroma.NameDecorator:       "preprocessor",
+	chroma.NameFunction:        "title",
+	chroma.NameTag:             "tag",
+	chroma.NameVariable:        "variable",
+	chroma.LiteralString:       "string",
+	chroma.LiteralStringRegex:  "regexp",
+	chroma.LiteralStringSymbol: "symbol",
+	chroma.LiteralNumber:       "number",
+	chroma.GenericDeleted:      "deletion",
+	chroma.GenericInserted:     "addition",
+	chroma.GenericSubheading:   "chunk",
+}
+
+// highlightClass is the class of tokens of type `typ`, the one of its
+// closest parent type if it has none.
+func highlightClass(typ chroma.TokenType) string {
+	for _, t := range []chroma.TokenType{typ, typ.SubCategory(), typ.Category()} {
+		if class, ok := highlightClasses[t]; ok {
+			return class
+		}
+	}
+	return ""
+}
+
+// highlightRenderer highlights fenced code blocks whose language it knows.
+type highlightRenderer struct {
+	blackfriday.Renderer
+}
+
+func (r *highlightRenderer) BlockCode(out *bytes.Buffer, text []byte, lang string) {
+	lang = strings.TrimSpace(lang)
+	if i := strings.IndexAny(lang, "\t ,"); i >= 0 {
+		// Fences can have more than a language, like "go,linenos"
+		lang = lang[:i]
+	}
+
+	lexer := lexers.Get(lang)
+	if lang == "" || lexer == nil {
+		r.Renderer.BlockCode(out, text, lang)
+		return
+	}
+	iter, err := chroma.Coalesce(lexer).Tokenise(nil, string(text))
+	if err != nil {
+		r.Renderer.BlockCode(out, text, lang)
+		return
+	}
+
+	if out.Len() > 0 {
+		out.WriteByte('\n')
+	}
+	out.WriteString("<pre><code class=\"language-")
+	out.WriteString(html.EscapeString(lang))
+	out.WriteString("\">")
+	for token := iter(); token != chroma.EOF; token = iter() {
+		class := highlightClass(token.Type)
+		if class == "" {
+			out.WriteString(html.EscapeString(token.Value))
+			continue
+		}
+		out.WriteString("<span class=\"" + class + "\">")
+		out.WriteString(html.EscapeString(token.Value))
+		out.WriteString("</span>")
+	}
+	out.WriteString("</code></pre>\n")
+}
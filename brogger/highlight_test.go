@@ -0,0 +1,36 @@
+package brogger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServerHighlighting(t *testing.T) {
+	markdown := []byte("```go\nfunc main() { // <3\n\treturn \"x\"\n}\n```\n")
+
+	content, _ := markdownWithHTML(markdown, true)
+	html := string(content)
+	for _, want := range []string{
+		`<pre><code class="language-go">`,
+		`<span class="keyword">func</span>`,
+		`<span class="title">main</span>`,
+		`<span class="comment">// &lt;3`,
+		`<span class="string">&#34;x&#34;</span>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Highlighted code is missing %s. Got %s", want, html)
+		}
+	}
+
+	content, _ = markdownWithHTML(markdown, false)
+	if strings.Contains(string(content), "<span") {
+		t.Error("Code is highlighted on the server when it shouldn't. Got", string(content))
+	}
+}
+
+func TestServerHighlightingUnknownLanguage(t *testing.T) {
+	content, _ := markdownWithHTML([]byte("```nosuchlang\na < b\n```\n"), true)
+	if !strings.Contains(string(content), "a &lt; b") || strings.Contains(string(content), "<span") {
+		t.Error("Code in an unknown language isn't left as is. Got", string(content))
+	}
+}
@@ -0,0 +1,322 @@
+package brogger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxCachedPages bounds the memory the page cache uses. Past it, the least
+// recently used pages are dropped, so that streams of one-off URLs like
+// searches don't push out the pages that are read the most.
+const maxCachedPages = 1000
+
+// maxCachedPageSize is the size past which a page isn't cached, but sent as
+// it's written. Each cached page is kept both as is and compressed.
+const maxCachedPageSize = 1 << 20
+
+// pageCache keeps the rendered pages until the posts or the templates
+// change. A nil pageCache caches nothing.
+type pageCache struct {
+	mu    sync.Mutex               // Locks everything, reading a page moves it up `lru`
+	gen   int                      // Incremented when the cached pages become stale
+	pages map[string]*list.Element // Elements of `lru`, by language and request URI
+	lru   *list.List               // Of *lruPage, most recently used first
+}
+
+// lruPage is a page in the list of the most recently used.
+type lruPage struct {
+	key  string
+	page *cachedPage
+}
+
+// cachedPage is a rendered page, ready to be served as is or compressed.
+type cachedPage struct {
+	header   http.Header // Headers of the response, but its cookies and encoding
+	body     []byte
+	gzipped  []byte
+	etag     string // Strong validator of `body`
+	gzipETag string // Strong validator of `gzipped`
+}
+
+func newPageCache() *pageCache {
+	return &pageCache{
+		pages: make(map[string]*list.Element),
+		lru:   list.New(),
+	}
+}
+
+func (c *pageCache) get(key string) (*cachedPage, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.pages[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*lruPage).page, true
+}
+
+// generation is to be given back to put, telling what the page was
+// rendered from.
+func (c *pageCache) generation() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// put caches `page`, unless things changed since generation `gen` it was
+// rendered from.
+func (c *pageCache) put(key string, gen int, page *cachedPage) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	if elem, ok := c.pages[key]; ok {
+		elem.Value.(*lruPage).page = page
+		c.lru.MoveToFront(elem)
+		return
+	}
+	for c.lru.Len() >= maxCachedPages {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.pages, oldest.Value.(*lruPage).key)
+	}
+	c.pages[key] = c.lru.PushFront(&lruPage{key: key, page: page})
+}
+
+// invalidate forgets all the pages, once the posts or the templates changed.
+func (c *pageCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.gen++
+	c.pages = make(map[string]*list.Element)
+	c.lru.Init()
+	c.mu.Unlock()
+}
+
+// contentChanged is called when the posts, pages or templates change, so
+// that pages rendered from the old ones aren't served anymore.
+func (b *Brog) contentChanged() {
+	if b == nil {
+		return
+	}
+	b.pages.invalidate()
+}
+
+// pageKey tells apart the pages rendered for `req`. Handlers take their
+// language from the query, in a way of their own like searches do, or else
+// from the language cookie: the key has both.
+func (b *Brog) pageKey(req *http.Request) string {
+	lang := ""
+	if cookie, err := req.Cookie("lang"); err == nil {
+		lang = cookie.Value
+	}
+	return lang + " " + req.RequestURI
+}
+
+// cacheHandlerFunc serves the rendered pages from the page cache, rendering
+// and caching them when they aren't. Responses are compressed for the
+// clients that accept it, and validated with their ETag.
+func (b *Brog) cacheHandlerFunc(h http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Add("Vary", "Accept-Encoding")
+		if b.Config.Multilingual {
+			// Pages are rendered in the language of the cookie
+			rw.Header().Add("Vary", "Cookie")
+		}
+		useGzip := acceptsGzip(req.Header.Get("Accept-Encoding"))
+
+		cache := b.pages != nil && (req.Method == "GET" || req.Method == "HEAD")
+		key := b.pageKey(req)
+		if page, ok := b.pages.get(key); ok && cache {
+			page.serve(rw, req, useGzip)
+			return
+		}
+
+		gen := b.pages.generation()
+		rec := newPageRecorder(rw, useGzip, cache)
+		h.ServeHTTP(rec, req)
+		rec.finish()
+		if rec.body == nil {
+			// Not cacheable, it was sent already
+			return
+		}
+
+		page := newCachedPage(rw.Header(), rec.body.Bytes())
+		b.pages.put(key, gen, page)
+		page.serve(rw, req, useGzip)
+	})
+}
+
+func newCachedPage(header http.Header, body []byte) *cachedPage {
+	page := &cachedPage{
+		header: make(http.Header, len(header)),
+		body:   body,
+	}
+	for name, values := range header {
+		switch name {
+		case "Set-Cookie", "Content-Length", "Content-Encoding":
+		default:
+			page.header[name] = values
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	gz := gzip.NewWriter(buf)
+	// Writing to a memory buffer can't fail
+	_, _ = gz.Write(body)
+	_ = gz.Close()
+	page.gzipped = buf.Bytes()
+
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:16])
+	page.etag = `"` + hash + `"`
+	page.gzipETag = `"` + hash + `-gzip"`
+	return page
+}
+
+// serve writes the page, compressed if `useGzip`, or tells the client that
+// the copy it has is still good.
+func (page *cachedPage) serve(rw http.ResponseWriter, req *http.Request, useGzip bool) {
+	h := rw.Header()
+	for name, values := range page.header {
+		h[name] = values
+	}
+	body, etag := page.body, page.etag
+	if useGzip {
+		body, etag = page.gzipped, page.gzipETag
+		h.Set("Content-Encoding", "gzip")
+	}
+	h.Set("ETag", etag)
+
+	if page.notModified(req) {
+		h.Del("Content-Type")
+		rw.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	rw.WriteHeader(http.StatusOK)
+	if req.Method != "HEAD" {
+		_, _ = rw.Write(body)
+	}
+}
+
+// notModified tells if the client making `req` already has the page, with
+// either encoding. Pages are only validated by their ETag: nothing tells
+// when what a page was rendered from last changed.
+func (page *cachedPage) notModified(req *http.Request) bool {
+	for _, etag := range strings.Split(req.Header.Get("If-None-Match"), ",") {
+		etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+		if etag == "*" || etag == page.etag || etag == page.gzipETag {
+			return true
+		}
+	}
+	return false
+}
+
+// pageRecorder keeps a rendered page in memory if it can be cached, and
+// otherwise sends it as it's written, compressed if the client accepts it.
+type pageRecorder struct {
+	rw      http.ResponseWriter // Gets the pages that aren't cached
+	cache   bool                // Whether the page can be cached, if its response allows it
+	status  int                 // Status of the response, 0 until it's written
+	decided bool                // Whether it's known if the page is cached
+	body    *bytes.Buffer       // The page, if it's cached
+}
+
+func newPageRecorder(rw http.ResponseWriter, useGzip, cache bool) *pageRecorder {
+	rec := &pageRecorder{rw: rw, cache: cache}
+	if useGzip {
+		rec.rw = newGzipResponseWriter(rw)
+	}
+	return rec
+}
+
+func (rec *pageRecorder) Header() http.Header {
+	return rec.rw.Header()
+}
+
+func (rec *pageRecorder) WriteHeader(code int) {
+	if rec.status != 0 {
+		return
+	}
+	rec.status = code
+	if rec.Header().Get("Content-Type") != "" || !hasBody(code) {
+		rec.decide()
+	}
+}
+
+func (rec *pageRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	if !rec.decided {
+		if rec.Header().Get("Content-Type") == "" {
+			rec.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		rec.decide()
+	}
+	if rec.body != nil && rec.body.Len()+len(b) > maxCachedPageSize {
+		rec.send()
+	}
+	if rec.body != nil {
+		return rec.body.Write(b)
+	}
+	return rec.rw.Write(b)
+}
+
+// decide caches the successful text responses that aren't private nor too
+// large. Others, like images of page bundles, are sent right away.
+func (rec *pageRecorder) decide() {
+	rec.decided = true
+	h := rec.Header()
+	cacheControl := h.Get("Cache-Control")
+	length, err := strconv.Atoi(h.Get("Content-Length"))
+	if rec.cache && rec.status == http.StatusOK && compressible(h.Get("Content-Type")) &&
+		!strings.Contains(cacheControl, "no-store") && !strings.Contains(cacheControl, "private") &&
+		(err != nil || length <= maxCachedPageSize) {
+		rec.body = bytes.NewBuffer(nil)
+		return
+	}
+	rec.rw.WriteHeader(rec.status)
+}
+
+// send gives up on caching the page once it's too large, sending what was
+// written of it so far.
+func (rec *pageRecorder) send() {
+	body := rec.body
+	rec.body = nil
+	rec.rw.WriteHeader(rec.status)
+	// The client is gone if it fails, the handler sees it on its next write
+	_, _ = body.WriteTo(rec.rw)
+}
+
+// finish sends what's left of a response that isn't cached.
+func (rec *pageRecorder) finish() {
+	if rec.status != 0 && !rec.decided {
+		rec.decide()
+	}
+	if gzrw, ok := rec.rw.(*gzipResponseWriter); ok {
+		_ = gzrw.Close()
+	}
+}
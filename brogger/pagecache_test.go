@@ -0,0 +1,200 @@
+package brogger
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheHandlerCaches(t *testing.T) {
+	b := SetUpDefaultBrog()
+	b.pages = newPageCache()
+	renders := 0
+	h := b.cacheHandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		renders++
+		if req.URL.Path == "/preview" {
+			rw.Header().Set("Cache-Control", "private, no-store")
+		}
+		rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = rw.Write([]byte("<p>rendered</p>"))
+	})
+	get := func(uri string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", uri, nil)
+		req.RequestURI = uri
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	first := get("/posts/hello")
+	second := get("/posts/hello")
+	if renders != 1 {
+		t.Fatalf("Page rendered %d times, expected once", renders)
+	}
+	if second.Body.String() != first.Body.String() || second.Header().Get("Content-Encoding") != "gzip" {
+		t.Error("Cached page differs from the rendered one")
+	}
+
+	req, _ := http.NewRequest("GET", "/posts/hello", nil)
+	req.RequestURI = "/posts/hello"
+	plain := httptest.NewRecorder()
+	h(plain, req)
+	if renders != 1 || plain.Body.String() != "<p>rendered</p>" || plain.Header().Get("Content-Encoding") != "" {
+		t.Error("Cached page isn't served uncompressed to clients without gzip")
+	}
+
+	b.contentChanged()
+	get("/posts/hello")
+	if renders != 2 {
+		t.Error("Page wasn't rendered again after the content changed")
+	}
+
+	get("/preview")
+	get("/preview")
+	if renders != 4 {
+		t.Error("Private page was cached")
+	}
+
+	// Rendered from content that changed meanwhile
+	gen := b.pages.generation()
+	b.contentChanged()
+	b.pages.put("stale", gen, &cachedPage{})
+	if _, ok := b.pages.get("stale"); ok {
+		t.Error("Page rendered before a change was cached")
+	}
+}
+
+func TestCacheHandlerConditional(t *testing.T) {
+	b := SetUpDefaultBrog()
+	b.pages = newPageCache()
+	h := b.cacheHandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = rw.Write([]byte("<p>rendered</p>"))
+	})
+	get := func(header http.Header) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/posts/hello", nil)
+		req.RequestURI = "/posts/hello"
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+
+	first := get(nil)
+	etag := first.Header().Get("ETag")
+	if etag == "" || strings.HasPrefix(etag, "W/") {
+		t.Fatalf("Expected a strong ETag, got %q", etag)
+	}
+	if lastModified := first.Header().Get("Last-Modified"); lastModified != "" {
+		t.Errorf("Page has a modification time it can't know, %q", lastModified)
+	}
+	gzipped := get(http.Header{"Accept-Encoding": {"gzip"}})
+	if gzipped.Header().Get("ETag") == etag {
+		t.Error("Compressed and uncompressed pages have the same strong ETag")
+	}
+
+	tests := []struct {
+		header http.Header
+		status int
+	}{
+		{http.Header{"If-None-Match": {etag}}, http.StatusNotModified},
+		{http.Header{"If-None-Match": {`"other", ` + gzipped.Header().Get("ETag")}}, http.StatusNotModified},
+		{http.Header{"If-None-Match": {`"other"`}}, http.StatusOK},
+		{http.Header{"If-Modified-Since": {time.Now().UTC().Format(http.TimeFormat)}}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		rec := get(tt.header)
+		if rec.Code != tt.status {
+			t.Errorf("With %v, expected status %d, got %d", tt.header, tt.status, rec.Code)
+		}
+		if rec.Code == http.StatusNotModified && rec.Body.Len() != 0 {
+			t.Errorf("With %v, not modified response has a body", tt.header)
+		}
+	}
+
+	b.contentChanged()
+	if rec := get(http.Header{"If-None-Match": {etag}}); rec.Code != http.StatusNotModified {
+		t.Error("Page rendered the same after a change isn't validated by its ETag")
+	}
+}
+
+func TestPageCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newPageCache()
+	gen := c.generation()
+	c.put("hot", gen, &cachedPage{})
+	for i := 0; i < maxCachedPages; i++ {
+		// Read the most, one-off pages keep coming
+		if _, ok := c.get("hot"); !ok {
+			t.Fatalf("Page read the most was dropped after %d others", i)
+		}
+		c.put(fmt.Sprintf("/search?q=%d", i), gen, &cachedPage{})
+	}
+	if _, ok := c.get("/search?q=0"); ok {
+		t.Error("Least recently used page wasn't dropped")
+	}
+	if len(c.pages) != maxCachedPages || c.lru.Len() != maxCachedPages {
+		t.Errorf("Expected %d cached pages, got %d", maxCachedPages, len(c.pages))
+	}
+}
+
+func TestCacheHandlerSearchLanguage(t *testing.T) {
+	b := SetUpDefaultBrog()
+	MakeBrogMultilingual(b)
+	b.pages = newPageCache()
+	b.postMngr = SetUpPostManager()
+	b.postMngr.SetPost(&post{id: "hello", Title: "Hello", text: "golang", Language: "en", Date: time.Now()})
+	b.postMngr.SetPost(&post{id: "bonjour", Title: "Bonjour", text: "golang", Language: "fr", Date: time.Now()})
+	h := b.cacheHandlerFunc(b.searchJSONFunc)
+
+	for _, tt := range []struct{ lang, want, other string }{
+		{"fr", "bonjour", "hello"},
+		{"en", "hello", "bonjour"},
+	} {
+		req, _ := http.NewRequest("GET", "/search.json?q=golang", nil)
+		req.RequestURI = "/search.json?q=golang"
+		req.AddCookie(&http.Cookie{Name: "lang", Value: tt.lang})
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		body := rec.Body.String()
+		if !strings.Contains(body, `"id":"`+tt.want+`"`) || strings.Contains(body, `"id":"`+tt.other+`"`) {
+			t.Errorf("Searching in %s, got %s", tt.lang, body)
+		}
+		if vary := rec.Header()["Vary"]; len(vary) != 2 || vary[1] != "Cookie" {
+			t.Errorf("Response in the language of the cookie varies with %v", vary)
+		}
+	}
+}
+
+func TestCacheHandlerLargePages(t *testing.T) {
+	b := SetUpDefaultBrog()
+	b.pages = newPageCache()
+	renders := 0
+	chunk := strings.Repeat("<p>rendered</p>\n", 1024)
+	chunks := maxCachedPageSize/len(chunk) + 1
+	h := b.cacheHandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		renders++
+		rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+		for i := 0; i < chunks; i++ {
+			_, _ = rw.Write([]byte(chunk))
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", "/large", nil)
+		req.RequestURI = "/large"
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		if rec.Code != http.StatusOK || rec.Body.String() != strings.Repeat(chunk, chunks) {
+			t.Fatalf("Large page wasn't sent whole, got status %d and %d bytes", rec.Code, rec.Body.Len())
+		}
+	}
+	if renders != 2 {
+		t.Errorf("Page larger than %d bytes rendered %d times, expected it not cached", maxCachedPageSize, renders)
+	}
+}
@@ -0,0 +1,80 @@
+package brogger
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/aybabtme/log"
+)
+
+// pagination tells templates where a page of posts stands among all the
+// pages of the index.
+type pagination struct {
+	Page       int    // Current page, starting at 1
+	TotalPages int    // How many pages there are, at least 1
+	PrevURL    string // Empty on the first page
+	NextURL    string // Empty on the last page
+}
+
+// pageURL is the URL of page `n` of the index.
+func pageURL(n int) string {
+	if n == 1 {
+		return "/"
+	}
+	return "/page/" + strconv.Itoa(n) + "/"
+}
+
+// paginate returns the posts shown on `page`, or false if there is no such
+// page. A `perPage` of 0 puts all the posts on a single page.
+func paginate(posts []*post, page, perPage int) ([]*post, *pagination, bool) {
+	if perPage <= 0 {
+		perPage = len(posts)
+	}
+
+	total := 1
+	if perPage != 0 && len(posts) > perPage {
+		total = (len(posts) + perPage - 1) / perPage
+	}
+	if page < 1 || page > total {
+		return nil, nil, false
+	}
+
+	pager := &pagination{Page: page, TotalPages: total}
+	if page > 1 {
+		pager.PrevURL = pageURL(page - 1)
+	}
+	if page < total {
+		pager.NextURL = pageURL(page + 1)
+	}
+
+	start := (page - 1) * perPage
+	end := start + perPage
+	if end > len(posts) {
+		end = len(posts)
+	}
+	return posts[start:end], pager, true
+}
+
+func (b *Brog) pageOfIndexFunc(rw http.ResponseWriter, req *http.Request) {
+
+	lang, _ := b.extractLanguage(req)
+
+	reqPath := strings.SplitN(req.RequestURI, "?", 2)[0]
+	page, err := strconv.Atoi(path.Base(reqPath))
+	if err != nil {
+		b.notFound(rw, req)
+		return
+	}
+
+	data, ok := b.indexContent(lang, page)
+	if !ok {
+		b.notFound(rw, req)
+		return
+	}
+
+	if err := b.render(rw, req, b.tmplMngr.DoWithIndex, data); err != nil {
+		log.Err(err).KV("page", page).Error("couldn't render index template")
+	}
+}
@@ -0,0 +1,42 @@
+package brogger
+
+import (
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	posts := make([]*post, 5)
+	for i := range posts {
+		posts[i] = &post{}
+	}
+
+	page, pager, ok := paginate(posts, 1, 2)
+	if !ok || len(page) != 2 {
+		t.Fatal("First page should hold 2 posts")
+	}
+	if pager.TotalPages != 3 || pager.PrevURL != "" || pager.NextURL != "/page/2/" {
+		t.Errorf("Wrong pagination for first page: %+v", pager)
+	}
+
+	page, pager, ok = paginate(posts, 3, 2)
+	if !ok || len(page) != 1 || page[0] != posts[4] {
+		t.Fatal("Last page should hold the last post")
+	}
+	if pager.PrevURL != "/page/2/" || pager.NextURL != "" {
+		t.Errorf("Wrong pagination for last page: %+v", pager)
+	}
+
+	if _, _, ok := paginate(posts, 4, 2); ok {
+		t.Error("Page past the last one should not exist")
+	}
+	if _, _, ok := paginate(posts, 0, 2); ok {
+		t.Error("Page 0 should not exist")
+	}
+
+	if page, pager, ok := paginate(posts, 1, 0); !ok || len(page) != 5 || pager.TotalPages != 1 {
+		t.Error("No posts per page setting should put all posts on one page")
+	}
+	if _, pager, ok := paginate(nil, 1, 2); !ok || pager.TotalPages != 1 {
+		t.Error("An empty index should still have one page")
+	}
+}
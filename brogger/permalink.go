@@ -0,0 +1,52 @@
+package brogger
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Placeholders of permalink patterns, replaced by the value of the post.
+const (
+	permalinkYear  = ":year"
+	permalinkMonth = ":month"
+	permalinkDay   = ":day"
+	permalinkSlug  = ":slug"
+	permalinkID    = ":id"
+	permalinkLang  = ":lang"
+)
+
+// pagePermalink is the permalink pattern of pages.
+const pagePermalink = "/pages/" + permalinkSlug
+
+func validPermalink(pattern string) error {
+	if !strings.HasPrefix(pattern, "/") {
+		return fmt.Errorf("must start with '/'")
+	}
+	if !strings.Contains(pattern, permalinkSlug) && !strings.Contains(pattern, permalinkID) {
+		return fmt.Errorf("must contain '%s' or '%s' to tell posts apart", permalinkSlug, permalinkID)
+	}
+	return nil
+}
+
+// expandPermalink replaces the placeholders of `pattern` with the values
+// of `p`.
+func expandPermalink(pattern string, p *post) string {
+	return strings.NewReplacer(
+		permalinkYear, fmt.Sprintf("%04d", p.Date.Year()),
+		permalinkMonth, fmt.Sprintf("%02d", int(p.Date.Month())),
+		permalinkDay, fmt.Sprintf("%02d", p.Date.Day()),
+		permalinkSlug, p.GetSlug(),
+		permalinkID, p.GetID(),
+		permalinkLang, url.PathEscape(p.Language),
+	).Replace(pattern)
+}
+
+// permalinkKey normalizes `urlpath` so that it finds its post whether it
+// has a trailing slash or not.
+func permalinkKey(urlpath string) string {
+	if urlpath == "/" {
+		return urlpath
+	}
+	return strings.TrimSuffix(urlpath, "/")
+}
@@ -0,0 +1,73 @@
+package brogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandPermalink(t *testing.T) {
+	p := &post{id: "hello+world", Language: "en", Date: time.Date(2014, 3, 7, 0, 0, 0, 0, time.UTC)}
+
+	if got := expandPermalink("/:year/:month/:day/:slug/", p); got != "/2014/03/07/hello+world/" {
+		t.Error("Placeholders aren't replaced by the values of the post. Got", got)
+	}
+
+	p.Slug = "bonjour le monde"
+	if got := expandPermalink("/:lang/:slug", p); got != "/en/bonjour%20le%20monde" {
+		t.Error("Slug doesn't override the ID, or isn't escaped. Got", got)
+	}
+	if got := expandPermalink("/posts/:id", p); got != "/posts/hello+world" {
+		t.Error("ID placeholder doesn't keep the ID. Got", got)
+	}
+}
+
+func TestValidPermalink(t *testing.T) {
+	for _, pattern := range []string{"/posts/:slug", "/:year/:month/:slug/", "/:id"} {
+		if err := validPermalink(pattern); err != nil {
+			t.Errorf("Pattern '%s' should be valid, got %v", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"posts/:slug", "/:year/:month/"} {
+		if err := validPermalink(pattern); err == nil {
+			t.Errorf("Pattern '%s' should be invalid", pattern)
+		}
+	}
+}
+
+func TestPostAtPermalink(t *testing.T) {
+	pmgr := SetUpPostManager()
+	pmgr.permalink = "/:year/:slug/"
+
+	first := &post{id: "first", Date: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)}
+	pmgr.SetPost(first)
+
+	if first.Permalink() != "/2014/first/" {
+		t.Fatal("Post manager didn't set the permalink of the post. Got", first.Permalink())
+	}
+	for _, urlpath := range []string{"/2014/first/", "/2014/first"} {
+		if p, ok := pmgr.GetPostAt(urlpath); !ok || p != first {
+			t.Errorf("Post isn't found at '%s'", urlpath)
+		}
+	}
+
+	// A second post with the same slug hides the first one until it's gone
+	second := &post{id: "second", Slug: "first", Date: time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC)}
+	pmgr.SetPost(second)
+	if p, _ := pmgr.GetPostAt("/2014/first/"); p != second {
+		t.Error("Last post set doesn't take over a shared permalink")
+	}
+	pmgr.DeletePost(second)
+	if p, _ := pmgr.GetPostAt("/2014/first/"); p != first {
+		t.Error("Permalink isn't given back when the post that took it is deleted")
+	}
+
+	// Changing the slug of a post moves it
+	renamed := &post{id: "first", Slug: "renamed", Date: first.Date}
+	pmgr.SetPost(renamed)
+	if _, ok := pmgr.GetPostAt("/2014/first/"); ok {
+		t.Error("Post is still found at its old permalink")
+	}
+	if p, ok := pmgr.GetPostAt("/2014/renamed/"); !ok || p != renamed {
+		t.Error("Post isn't found at its new permalink")
+	}
+}
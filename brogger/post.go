@@ -2,48 +2,156 @@ package brogger
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"github.com/russross/blackfriday"
+	"html"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 )
 
+const (
+	// moreMarker ends the excerpt of a post, when it has no abstract.
+	moreMarker = "<!--more-->"
+	// Length of the excerpt of a post without abstract nor `moreMarker`.
+	excerptWords   = 50
+	wordsPerMinute = 200
+)
+
 type post struct {
-	filename string
-	id       string
+	filename  string
+	id        string
+	permalink string    // URL of the post, set by its postManager
+	text      string    // Content without its markup
+	format    string    // Format of the front matter, JSON, YAML or TOML
+	modTime   time.Time // When its file was last modified
+	headings  []heading // Sections of the content, in order
+	bundle    string    // Directory of its page bundle, if it's the index of one
 
-	Title     string    `json:"title"`
-	Date      time.Time `json:"date"`
-	Author    string    `json:"author"`
-	Invisible bool      `json:"invisible"`
-	Abstract  string    `json:"abstract"`
-	Language  string    `json:"language"`
-	Content   string    `json:"-"` // Loaded from the Markdown part
+	Title       string     `json:"title"`
+	Date        time.Time  `json:"date"`
+	Author      string     `json:"author"`
+	Invisible   bool       `json:"invisible"`
+	Abstract    string     `json:"abstract"`
+	Language    string     `json:"language"`
+	Translation string     `json:"translation,omitempty"` // Shared by the translations of the post
+	Slug        string     `json:"slug,omitempty"`        // Replaces the filename in permalinks
+	Aliases     []string   `json:"aliases,omitempty"`     // Old URLs redirecting to the post
+	Tags        []string   `json:"tags,omitempty"`
+	Categories  []string   `json:"categories,omitempty"`
+	Series      string     `json:"series,omitempty"`      // Name of the series the post is part of
+	SeriesOrder int        `json:"seriesOrder,omitempty"` // Position of the post in its series
+	ShowTOC     *bool      `json:"toc,omitempty"`         // Forces the table of contents on or off
+	Expires     *time.Time `json:"expires,omitempty"`     // Hides the post from that date
+	Content     string     `json:"-"`                     // Loaded from the Markdown part
+	WordCount   int        `json:"-"`                     // Words in the content
+	ReadingTime int        `json:"-"`                     // Minutes to read the content
+	Excerpt     string     `json:"-"`                     // Abstract, or the beginning of the content
 }
 
 func (p *post) GetID() string {
 	return p.id
 }
 
-func (p *post) setID() {
-	p.id = url.QueryEscape(stripExtension(p.filename))
+// isPublished tells if the post is visible at time `now`: it isn't invisible,
+// its date has come and it hasn't expired yet.
+func (p *post) isPublished(now time.Time) bool {
+	if p.Invisible || p.Date.After(now) {
+		return false
+	}
+	return p.Expires == nil || now.Before(*p.Expires)
 }
 
-func (p *post) exportToFile(filename string) error {
-	postBuf := bytes.NewBuffer(nil)
+// nextPublicationChange is the next time after `now` at which the post
+// becomes published or expires, or false if that never happens.
+func (p *post) nextPublicationChange(now time.Time) (time.Time, bool) {
+	if p.Invisible {
+		return time.Time{}, false
+	}
+	if p.Date.After(now) {
+		return p.Date, true
+	}
+	if p.Expires != nil && p.Expires.After(now) {
+		return *p.Expires, true
+	}
+	return time.Time{}, false
+}
+
+// lastModified is when the post last changed, its date or the last write
+// to its file, whichever is later.
+func (p *post) lastModified() time.Time {
+	if p.modTime.After(p.Date) {
+		return p.modTime
+	}
+	return p.Date
+}
 
-	data, err := json.MarshalIndent(p, "", strings.Repeat(" ", JSONIndentCount))
+// TOC is the table of contents of the post, as HTML. It's empty if the
+// post has too few headings, unless its front matter asks for it.
+func (p *post) TOC() string {
+	show := len(p.headings) >= minTOCHeadings
+	if p.ShowTOC != nil {
+		show = *p.ShowTOC
+	}
+	if !show {
+		return ""
+	}
+	return renderTOC(p.headings)
+}
+
+// IsDraft tells if the post isn't published yet, or anymore.
+func (p *post) IsDraft() bool {
+	return !p.isPublished(time.Now())
+}
+
+// GetSlug is the slug of the post if it has one, its ID otherwise. The slug
+// only replaces the filename, posts in subdirectories stay under them.
+func (p *post) GetSlug() string {
+	if slug := strings.TrimSpace(p.Slug); slug != "" {
+		dir := p.GetID()[:strings.LastIndex(p.GetID(), "/")+1]
+		return dir + url.PathEscape(slug)
+	}
+	return p.GetID()
+}
+
+// Permalink is the URL path at which the post is served.
+func (p *post) Permalink() string {
+	return p.permalink
+}
+
+// setID names the post after the path of its file under `root`, the
+// directory of its postManager, like `2014/hello`. Keeping the
+// subdirectories in IDs tells apart files with the same name in different
+// subdirectories.
+func (p *post) setID(root string) {
+	name := filepath.Join(filepath.Dir(p.filename), stripExtension(p.filename))
+	if p.bundle != "" {
+		// Named after its page bundle, all of them being `index`
+		name = p.bundle
+	}
+	rel, err := filepath.Rel(root, name)
 	if err != nil {
-		return fmt.Errorf("encoding post to JSON, %v", err)
+		rel = filepath.Base(name)
 	}
 
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i, part := range parts {
+		parts[i] = url.QueryEscape(part)
+	}
+	p.id = strings.Join(parts, "/")
+}
+
+func (p *post) exportToFile(filename string) error {
+	postBuf := bytes.NewBuffer(nil)
+
 	// Writing to a memory buffer can't fail (well...)
-	_, _ = postBuf.Write(data)
-	_, _ = postBuf.WriteString("\n" + p.Content)
+	if err := writeFrontMatter(postBuf, p); err != nil {
+		return err
+	}
+	_, _ = postBuf.WriteString(p.Content)
 
 	if err := ioutil.WriteFile(filename, postBuf.Bytes(), 0640); err != nil {
 		return fmt.Errorf("writing post's bytes to '%s', %v", filename, err)
@@ -51,48 +159,55 @@ func (p *post) exportToFile(filename string) error {
 	return nil
 }
 
-func newPostFromFile(filename string) (*post, error) {
+// newPostFromFile loads the post in `filename`, highlighting its code blocks
+// if `highlightCode` is set.
+func newPostFromFile(filename string, highlightCode bool) (*post, error) {
 
 	post := post{filename: filename}
 
-	postFile, err := os.Open(filename)
+	data, err := ioutil.ReadFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("opening file '%s', %v", filename, err)
+		return nil, fmt.Errorf("reading file '%s', %v", filename, err)
 	}
 
-	dec := json.NewDecoder(postFile)
-	if err = dec.Decode(&post); err != nil {
-		return nil, fmt.Errorf("reading json header of post '%s', %v", filename, err)
+	if info, err := os.Stat(filename); err == nil {
+		post.modTime = info.ModTime()
 	}
 
-	// Read content after that JSON header.  json.Decoder buffers some data, so
-	// we need to ask for a buffered reader on it's current position.
-	markdownBuffered, err := ioutil.ReadAll(dec.Buffered())
+	markdownContent, err := readFrontMatter(data, &post)
 	if err != nil {
-		return nil, fmt.Errorf("reading JSON.decoder's buffered markdown for '%s', %v", post.Title, err)
+		return nil, fmt.Errorf("reading front matter of post '%s', %v", filename, err)
 	}
 
-	// We also need to read anything that is left from the original reader
-	// and wasn't in the JSON.decoder's buffers
-	markdownMissing, err := ioutil.ReadAll(postFile)
-	if err != nil {
-		return nil, fmt.Errorf("reading original reader's markdown for '%s', %v", post.Title, err)
-	}
+	htmlContent, headings := markdownWithHTML(markdownContent, highlightCode)
+	post.Content = string(htmlContent)
+	post.headings = headings
+	post.text = plainText(post.Content)
+	post.summarize()
 
-	markdownContent := make([]byte, len(markdownBuffered)+len(markdownMissing))
-	copy(markdownContent, markdownBuffered)
-	copy(markdownContent[len(markdownBuffered):], markdownMissing)
+	post.setID(filepath.Dir(filename))
 
-	htmlContent := markdownWithHTML(markdownContent)
-	post.Content = string(htmlContent)
+	return &post, nil
+}
 
-	post.setID()
+// summarize counts the words of the post and excerpts its content, which
+// must have been loaded.
+func (p *post) summarize() {
+	words := strings.Fields(p.text)
+	p.WordCount = len(words)
+	p.ReadingTime = (p.WordCount + wordsPerMinute - 1) / wordsPerMinute
 
-	if err := postFile.Close(); err != nil {
-		return &post, fmt.Errorf("closing post file, %v", err)
+	// Excerpts are shown like abstracts, as HTML
+	switch {
+	case strings.TrimSpace(p.Abstract) != "":
+		p.Excerpt = p.Abstract
+	case strings.Contains(p.Content, moreMarker):
+		p.Excerpt = html.EscapeString(plainText(p.Content[:strings.Index(p.Content, moreMarker)]))
+	case len(words) > excerptWords:
+		p.Excerpt = html.EscapeString(strings.Join(words[:excerptWords], " ")) + "&hellip;"
+	default:
+		p.Excerpt = html.EscapeString(p.text)
 	}
-
-	return &post, nil
 }
 
 type postList struct {
@@ -112,14 +227,20 @@ func (p postList) Swap(i, j int) {
 	p.posts[i], p.posts[j] = p.posts[j], p.posts[i]
 }
 
-func markdownWithHTML(input []byte) []byte {
+// markdownWithHTML renders `input` to HTML, and lists its headings. Code
+// blocks are highlighted if `highlightCode` is set.
+func markdownWithHTML(input []byte, highlightCode bool) ([]byte, []heading) {
 
 	htmlFlags := 0
 	htmlFlags |= blackfriday.HTML_USE_XHTML
 	htmlFlags |= blackfriday.HTML_USE_SMARTYPANTS
 	htmlFlags |= blackfriday.HTML_SMARTYPANTS_FRACTIONS
 	htmlFlags |= blackfriday.HTML_SMARTYPANTS_LATEX_DASHES
-	renderer := blackfriday.HtmlRenderer(htmlFlags, "", "")
+	htmlRenderer := blackfriday.HtmlRenderer(htmlFlags, "", "")
+	if highlightCode {
+		htmlRenderer = &highlightRenderer{htmlRenderer}
+	}
+	renderer := newHeadingRenderer(htmlRenderer)
 
 	// set up the parser
 	extensions := 0
@@ -130,6 +251,57 @@ func markdownWithHTML(input []byte) []byte {
 	extensions |= blackfriday.EXTENSION_STRIKETHROUGH
 	extensions |= blackfriday.EXTENSION_SPACE_HEADERS
 	extensions |= blackfriday.EXTENSION_LAX_HTML_BLOCKS
+	extensions |= blackfriday.EXTENSION_HEADER_IDS
+
+	htmlContent := blackfriday.Markdown(input, renderer, extensions)
+	return htmlContent, renderer.headings
+}
 
-	return blackfriday.Markdown(input, renderer, extensions)
+// blockTags are the HTML elements that separate words, unlike inline ones
+// like <em> or the <span> of highlighted code which can be in the middle of
+// a word.
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"br": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"figcaption": true, "figure": true, "footer": true, "h1": true,
+	"h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "ol": true, "p": true,
+	"pre": true, "section": true, "table": true, "td": true, "th": true,
+	"tr": true, "ul": true,
+}
+
+// plainText strips the tags out of `htmlContent` and collapses its
+// whitespace, leaving only the text a reader would see.
+func plainText(htmlContent string) string {
+	buf := bytes.NewBuffer(nil)
+	tag := bytes.NewBuffer(nil)
+	inTag := false
+	for _, r := range htmlContent {
+		switch {
+		case r == '<':
+			inTag = true
+			tag.Reset()
+		case r == '>' && inTag:
+			inTag = false
+			// Blocks separate words, like in "<li>a</li><li>b</li>"
+			if blockTags[tagName(tag.String())] {
+				_, _ = buf.WriteRune(' ')
+			}
+		case inTag:
+			_, _ = tag.WriteRune(r)
+		default:
+			_, _ = buf.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(buf.String())), " ")
+}
+
+// tagName is the lowercase name of the element of `tag`, the inside of an
+// opening or closing tag.
+func tagName(tag string) string {
+	tag = strings.TrimPrefix(tag, "/")
+	if i := strings.IndexAny(tag, " \t\n/"); i >= 0 {
+		tag = tag[:i]
+	}
+	return strings.ToLower(tag)
 }
@@ -2,52 +2,93 @@ package brogger
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aybabtme/log"
-	"github.com/howeyc/fsnotify"
 )
 
 type postManager struct {
-	brog *Brog  // Reference to the Brog app for logging purpose
-	path string // Path on which the manager watch for post changes
-
-	watcher *fsnotify.Watcher // Listens on `path`
-	die     chan struct{}     // To kill the watcher goroutine
-
-	mu          sync.RWMutex     // Locks the `posts` and `sortedPosts`
-	posts       map[string]*post // All the posts, accessed by filename
-	sortedPosts []*post          // All the posts in most recent order
+	brog      *Brog  // Reference to the Brog app for logging purpose
+	path      string // Path on which the manager watch for post changes
+	permalink string // Pattern of the URLs of the posts
+
+	watcher watcher         // Listens on `path`
+	dirs    map[string]bool // Directories under `path` being watched
+	die     chan struct{}   // To kill the watcher goroutine
+
+	now func() time.Time // Tells the time posts are published and expire at
+
+	mu          sync.RWMutex        // Locks everything below
+	posts       map[string]*post    // All the posts, accessed by filename
+	sortedPosts []*post             // All the published posts in most recent order
+	sortedAll   []*post             // All the posts, drafts included, in most recent order
+	taxonomies  map[string]taxonomy // Visible posts in most recent order, by tag/category
+	archive     archive             // Visible posts by year and month, by language
+	series      map[string]*series  // Visible posts in reading order, by series name
+	search      *searchIndex        // Full-text index of all the posts
+	related     *relatedIndex       // Posts most related to each post, scored with `search`
+	byPermalink map[string]*post    // All the posts, accessed by permalink
+	byAlias     map[string]*post    // All the posts, accessed by their aliases
+	schedule    *time.Timer         // Sorts the posts again when one is published or expires
+	renamed     []renamedPost       // Posts whose file was renamed, waiting for their new name
+	loading     bool                // While all the posts load, their related posts are computed after
 }
 
-func startPostManager(brog *Brog, filepath string) (*postManager, error) {
-
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return nil, fmt.Errorf("getting post watcher, %v", err)
-	}
+// renamedPost is a post whose file was renamed, until the file with its new
+// name shows up.
+type renamedPost struct {
+	post *post
+	at   time.Time
+}
 
+// newPostManager loads all the posts found at `filepath`, without watching
+// them for changes. Posts are served at URLs following the `permalink`
+// pattern.
+func newPostManager(brog *Brog, filepath, permalink string) (*postManager, error) {
+	search := newSearchIndex()
 	postMngr := &postManager{
 		mu:          sync.RWMutex{},
 		brog:        brog,
 		path:        filepath,
+		permalink:   permalink,
 		posts:       make(map[string]*post),
 		sortedPosts: []*post{},
-		watcher:     watcher,
+		search:      search,
+		related:     newRelatedIndex(search),
+		byPermalink: make(map[string]*post),
+		byAlias:     make(map[string]*post),
+		dirs:        make(map[string]bool),
 		die:         make(chan struct{}),
+		now:         time.Now,
 	}
 
-	err = postMngr.loadAllPosts()
+	err := postMngr.loadAllPosts()
 	if err != nil {
 		return nil, fmt.Errorf("while loading all posts, %v", err)
 	}
 
 	postMngr.sortPosts()
+
+	return postMngr, nil
+}
+
+func startPostManager(brog *Brog, filepath, permalink string) (*postManager, error) {
+
+	postMngr, err := newPostManager(brog, filepath, permalink)
+	if err != nil {
+		return nil, err
+	}
+
+	postMngr.watcher, err = brog.newWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("getting post watcher, %v", err)
+	}
+
 	if err := postMngr.watchForChanges(filepath); err != nil {
 		return nil, fmt.Errorf("starting watch for changes on '%s', %v", filepath, err)
 	}
@@ -56,27 +97,41 @@ func startPostManager(brog *Brog, filepath string) (*postManager, error) {
 }
 
 func (p *postManager) loadAllPosts() error {
-	fileInfos, err := ioutil.ReadDir(p.path)
-	if err != nil {
-		return fmt.Errorf("listing directory '%s', %v", p.path, err)
-	}
+	p.mu.Lock()
+	p.loading = true
+	p.mu.Unlock()
 
-	for _, fileInfo := range fileInfos {
-		if !fileInfo.IsDir() {
+	err := p.walkPosts(p.path, func(filename string) {
+		if err := p.loadFromFile(filename); err != nil {
+			log.Err(err).KV("file.name", filename).Error("can't load post from file")
+		}
+	})
 
-			fullpath := filepath.Clean(p.path) +
-				string(os.PathSeparator) +
-				fileInfo.Name()
+	p.mu.Lock()
+	p.loading = false
+	p.related.rebuild()
+	p.mu.Unlock()
 
-			err := p.loadFromFile(fullpath)
-			if err != nil {
-				log.Err(err).KV("file.name", fileInfo.Name()).Error("can't load post from file")
-			}
-		}
+	if err != nil {
+		return fmt.Errorf("listing directory '%s', %v", p.path, err)
 	}
 	return nil
 }
 
+// walkPosts calls `load` with every post under `dirname`, in subdirectories
+// too.
+func (p *postManager) walkPosts(dirname string, load func(filename string)) error {
+	return filepath.Walk(dirname, func(fullpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && p.isPostFile(fullpath) {
+			load(fullpath)
+		}
+		return nil
+	})
+}
+
 func (p *postManager) GetAllPosts() []*post {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -91,25 +146,130 @@ func (p *postManager) GetAllPostsWithLanguage(lang string) []*post {
 	}
 	p.mu.RLock()
 	defer p.mu.RUnlock()
+	return filterLanguage(p.sortedPosts, lang)
+}
+
+// GetAllPostsWithDrafts is like GetAllPostsWithLanguage, but also returns
+// the posts that aren't published.
+func (p *postManager) GetAllPostsWithDrafts(lang string) []*post {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return filterLanguage(p.sortedAll, lang)
+}
+
+func filterLanguage(posts []*post, lang string) []*post {
 	var postCopy []*post
-	for _, val := range p.sortedPosts {
-		if val.Language == lang {
+	for _, val := range posts {
+		if lang == "" || val.Language == lang {
 			postCopy = append(postCopy, val)
 		}
 	}
 	return postCopy
 }
 
+// GetTags lists the tags of taxonomy `kind` used by posts in `lang`.
+func (p *postManager) GetTags(kind, lang string) []tag {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.taxonomies[kind].tags(lang)
+}
+
+// GetPostsWithTag lists the posts in `lang` classified under `term` in
+// taxonomy `kind`.
+func (p *postManager) GetPostsWithTag(kind, term, lang string) []*post {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.taxonomies[kind].posts(term, lang)
+}
+
+// GetArchive lists the visible posts in `lang` by year and month, for
+// `year` and `month` only if they aren't zero.
+func (p *postManager) GetArchive(lang string, year int, month time.Month) ([]*archiveYear, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.archive.period(lang, year, month)
+}
+
+// GetSeries lists the visible posts in `lang` that are part of series
+// `name`, in reading order.
+func (p *postManager) GetSeries(name, lang string) (*series, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	s, ok := p.series[name]
+	if !ok {
+		return nil, false
+	}
+	posts := filterLanguage(s.Posts, lang)
+	if len(posts) == 0 {
+		return nil, false
+	}
+	return &series{Name: s.Name, Posts: posts}, true
+}
+
+// GetAllSeries lists the series that have visible posts in `lang`, by name.
+func (p *postManager) GetAllSeries(lang string) []*series {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var all seriesList
+	for _, s := range p.series {
+		if posts := filterLanguage(s.Posts, lang); len(posts) != 0 {
+			all = append(all, &series{Name: s.Name, Posts: posts})
+		}
+	}
+	sort.Sort(all)
+	return all
+}
+
+// GetRelated lists the visible posts most related to `post`, most related
+// first.
+func (p *postManager) GetRelated(post *post) []*post {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.related.get(post, p.now())
+}
+
+// Search ranks the visible posts in `lang` that match `query`.
+func (p *postManager) Search(query, lang string) []searchResult {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.search.search(query, lang)
+}
+
 func (p *postManager) GetPost(key string) (*post, bool) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 	post, ok := p.posts[key]
-	if ok && post.Invisible {
+	if ok && !post.isPublished(p.now()) {
 		return nil, false
 	}
 	return post, ok
 }
 
+// GetPostAt finds the post whose permalink is `urlpath`, published or not.
+func (p *postManager) GetPostAt(urlpath string) (*post, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	post, ok := p.byPermalink[permalinkKey(urlpath)]
+	return post, ok
+}
+
+// GetPostWithAlias finds the post that lists `urlpath` in its aliases.
+func (p *postManager) GetPostWithAlias(urlpath string) (*post, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	post, ok := p.byAlias[aliasKey(urlpath)]
+	return post, ok
+}
+
+// GetPostOrDraft is like GetPost, but also returns posts that aren't
+// published.
+func (p *postManager) GetPostOrDraft(key string) (*post, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	post, ok := p.posts[key]
+	return post, ok
+}
+
 func (p *postManager) DeletePostWithFilename(filename string) (*post, bool) {
 
 	p.mu.RLock()
@@ -132,8 +292,34 @@ func (p *postManager) DeletePostWithFilename(filename string) (*post, bool) {
 }
 
 func (p *postManager) SetPost(post *post) {
+	post.permalink = expandPermalink(p.permalink, post)
+	key := permalinkKey(post.permalink)
+	if post.bundle != "" {
+		// Files of the bundle are served next to the post
+		post.Content = rewriteRelativeURLs(post.Content, post.bundleURL())
+	}
+
 	p.mu.Lock()
+	if old, ok := p.posts[post.GetID()]; ok {
+		p.deletePermalink(old)
+		p.deleteAliases(old)
+	}
+	if other, ok := p.byPermalink[key]; ok && other.GetID() != post.GetID() {
+		log.KV("post.id", post.GetID()).
+			KV("other.id", other.GetID()).
+			KV("post.permalink", post.permalink).
+			Error("posts have the same permalink, hiding the other one")
+	}
 	p.posts[post.GetID()] = post
+	p.byPermalink[key] = post
+	for _, alias := range post.Aliases {
+		p.byAlias[aliasKey(alias)] = post
+	}
+	p.search.add(post)
+	if !p.loading {
+		p.related.update(post)
+		p.related.refresh()
+	}
 	p.mu.Unlock()
 
 	p.sortPosts()
@@ -143,17 +329,73 @@ func (p *postManager) DeletePost(post *post) {
 	p.mu.Lock()
 
 	delete(p.posts, post.GetID())
+	p.deletePermalink(post)
+	p.deleteAliases(post)
+	p.search.remove(post.GetID())
+	p.related.remove(post.GetID())
+	p.related.refresh()
 	p.mu.Unlock()
 
 	p.sortPosts()
 }
 
+// deletePermalink forgets the permalink of `post`, unless another post took
+// it over. Must be called with `mu` locked.
+func (p *postManager) deletePermalink(post *post) {
+	key := permalinkKey(post.permalink)
+	if p.byPermalink[key] != post {
+		return
+	}
+	delete(p.byPermalink, key)
+	// Give the permalink back to a post it was taken from
+	for _, other := range p.posts {
+		if other != post && permalinkKey(other.permalink) == key {
+			p.byPermalink[key] = other
+			return
+		}
+	}
+}
+
+// deleteAliases forgets the aliases of `post`. Must be called with `mu`
+// locked.
+func (p *postManager) deleteAliases(post *post) {
+	for _, alias := range post.Aliases {
+		if key := aliasKey(alias); p.byAlias[key] == post {
+			delete(p.byAlias, key)
+		}
+	}
+}
+
+// recordMove redirects the old permalink of a post to the permalink of
+// `cur`, if they differ.
+func (p *postManager) recordMove(old, cur *post) {
+	if permalinkKey(old.Permalink()) == permalinkKey(cur.Permalink()) {
+		return
+	}
+	ll := log.KV("redirect.from", old.Permalink()).KV("redirect.to", cur.Permalink())
+	if p.brog == nil || p.brog.redirects == nil {
+		ll.Info("post moved, not keeping track of redirects")
+		return
+	}
+	if err := p.brog.redirects.Add(old.Permalink(), cur.Permalink()); err != nil {
+		ll.Err(err).Error("couldn't save redirect of moved post")
+		return
+	}
+	ll.Info("post moved, redirecting its old permalink")
+}
+
 func (p *postManager) sortPosts() {
-	var postL postList
+	var postL, allL postList
 
+	now := p.now()
+	var next time.Time
 	p.mu.RLock()
 	for _, val := range p.posts {
-		if val.Invisible {
+		if change, ok := val.nextPublicationChange(now); ok && (next.IsZero() || change.Before(next)) {
+			next = change
+		}
+		allL.posts = append(allL.posts, val)
+		if !val.isPublished(now) {
 			continue
 		}
 		postL.posts = append(postL.posts, val)
@@ -161,86 +403,214 @@ func (p *postManager) sortPosts() {
 	p.mu.RUnlock()
 
 	sort.Sort(postL)
+	sort.Sort(allL)
+
+	taxonomies := make(map[string]taxonomy, len(allTaxonomies))
+	for _, kind := range allTaxonomies {
+		taxonomies[kind] = newTaxonomy(kind, postL.posts)
+	}
+	archive := newArchive(postL.posts)
+	series := newSeriesIndex(postL.posts)
 
 	p.mu.Lock()
 	p.sortedPosts = postL.posts
+	p.sortedAll = allL.posts
+	p.taxonomies = taxonomies
+	p.archive = archive
+	p.series = series
+	p.reschedule(now, next)
 	p.mu.Unlock()
+	p.brog.contentChanged()
+}
+
+// reschedule arms the timer sorting the posts at `next`, the time of the
+// next publication or expiry. A zero `next` means there is none. Must be
+// called with `mu` locked.
+func (p *postManager) reschedule(now, next time.Time) {
+	if p.schedule != nil {
+		p.schedule.Stop()
+		p.schedule = nil
+	}
+	if next.IsZero() {
+		return
+	}
+	log.KV("dir.name", p.path).KV("schedule.time", next).Info("next post publication scheduled")
+	p.schedule = time.AfterFunc(next.Sub(now), p.sortPosts)
 }
 
 func (p *postManager) Close() error {
+	p.mu.Lock()
+	p.reschedule(time.Now(), time.Time{})
+	p.mu.Unlock()
+
+	if p.watcher == nil {
+		// Never started watching
+		return nil
+	}
 	p.die <- struct{}{}
 	return p.watcher.Close()
 }
 
 func (p *postManager) watchForChanges(dirname string) error {
+	if err := p.watchTree(dirname); err != nil {
+		return err
+	}
+
 	go func() {
 		ll := log.KV("dir.name", dirname)
+		queue := newEventQueue(p.processPostEvent)
 		for {
 			select {
-			case ev := <-p.watcher.Event:
-				p.processPostEvent(ev)
-			case err := <-p.watcher.Error:
+			case ev := <-p.watcher.Events():
+				queue.add(ev)
+			case <-queue.ready():
+				queue.flush()
+			case err := <-p.watcher.Errors():
 				ll.Err(err).Error("error watching posts")
 			case <-p.die:
+				queue.stop()
 				return
 			}
 		}
 	}()
-	return p.watcher.Watch(dirname)
+	return nil
+}
 
+// watchTree watches `dirname` and all the directories under it.
+func (p *postManager) watchTree(dirname string) error {
+	return filepath.Walk(dirname, func(fullpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if err := p.watcher.Watch(fullpath); err != nil {
+			return fmt.Errorf("watching directory '%s', %v", fullpath, err)
+		}
+		p.dirs[fullpath] = true
+		return nil
+	})
 }
 
-func (p *postManager) processPostEvent(ev *fsnotify.FileEvent) {
+func (p *postManager) processPostEvent(ev fileEvent, _ bool) error {
 
-	ext := strings.ToLower(filepath.Ext(ev.Name))
-	switch ext {
-	case p.brog.Config.PostFileExt:
-	case ".md":
-	case ".markdown":
-	case ".mkd":
-	default:
-		return
+	if handled, err := p.processDirEvent(ev); handled {
+		return err
 	}
 
-	if ev.IsCreate() {
-		p.processPostCreate(ev)
-		return
+	switch ev.op {
+	case fileCreated, fileModified:
+		if !p.isPostFile(ev.name) {
+			// Maybe a file of a page bundle, served from the page cache
+			p.brog.contentChanged()
+			return nil
+		}
+		return p.processPostChange(ev.name)
+	case fileRenamed:
+		p.processPostRename(ev.name)
+	case fileDeleted:
+		p.processPostDelete(ev.name)
 	}
+	return nil
+}
 
-	if ev.IsModify() {
-		p.processPostModify(ev)
-		return
+// isPostFile tells if `filename` is a post: a Markdown file under the posts
+// directory, unless it's part of a page bundle without being its index.
+func (p *postManager) isPostFile(filename string) bool {
+	if !p.brog.isMarkdown(filename) {
+		return false
 	}
+	bundle, ok := p.bundleOf(filename)
+	return !ok || (filepath.Dir(filename) == bundle && stripExtension(filename) == bundleIndexName)
+}
 
-	if ev.IsRename() {
-		p.processPostRename(ev)
-		return
+// processDirEvent handles directories coming and going under the posts
+// directory, along with the posts in them. It returns false if `ev` isn't
+// about a directory.
+func (p *postManager) processDirEvent(ev fileEvent) (bool, error) {
+	ll := log.KV("dir.name", ev.name)
+
+	switch ev.op {
+	case fileCreated:
+		info, err := os.Stat(ev.name)
+		if err != nil || !info.IsDir() {
+			return false, nil
+		}
+		ll.Info("new directory detected")
+		// Watched first, so that posts written in it from now on are seen
+		if err := p.watchTree(ev.name); err != nil {
+			ll.Err(err).Error("can't watch new directory")
+		}
+		var loadErr error
+		err = p.walkPosts(ev.name, func(filename string) {
+			if err := p.processPostChange(filename); err != nil {
+				loadErr = err
+			}
+		})
+		if err != nil {
+			return true, fmt.Errorf("listing posts of new directory, %v", err)
+		}
+		return true, loadErr
+
+	case fileRenamed, fileDeleted:
+		if !p.dirs[ev.name] {
+			return false, nil
+		}
+	default:
+		return false, nil
 	}
 
-	if ev.IsDelete() {
-		p.processPostDelete(ev)
-		return
+	ll.Info("directory removed")
+	prefix := ev.name + string(os.PathSeparator)
+	for dir := range p.dirs {
+		if dir == ev.name || strings.HasPrefix(dir, prefix) {
+			// Fails if the directory is already gone, nothing to do then
+			_ = p.watcher.RemoveWatch(dir)
+			delete(p.dirs, dir)
+		}
 	}
+	for _, post := range p.postsUnder(ev.name) {
+		if ev.op == fileRenamed {
+			p.processPostRename(post.filename)
+		} else {
+			p.processPostDelete(post.filename)
+		}
+	}
+	return true, nil
+}
 
-	log.KV("file.event", ev.String()).Error("unknown file event")
+// processPostChange loads the post in `filename` again, or for the first
+// time if it's new.
+func (p *postManager) processPostChange(filename string) error {
+	if _, ok := p.postWithFilename(filename); ok {
+		return p.processPostModify(filename)
+	}
+	return p.processPostCreate(filename)
 }
 
-func (p *postManager) processPostRename(ev *fsnotify.FileEvent) {
-	ll := log.KV("post.name", ev.Name)
+func (p *postManager) processPostRename(filename string) {
+	ll := log.KV("post.name", filename)
 	ll.Info("post name changed")
 
-	_, ok := p.DeletePostWithFilename(ev.Name)
+	post, ok := p.DeletePostWithFilename(filename)
 
 	if !ok {
 		ll.Error("unknown post, ignoring the rename")
+		return
 	}
+
+	// The file with the new name is created right after
+	p.mu.Lock()
+	p.renamed = append(p.renamed, renamedPost{post: post, at: time.Now()})
+	p.mu.Unlock()
 }
 
-func (p *postManager) processPostDelete(ev *fsnotify.FileEvent) {
-	ll := log.KV("post.name", ev.Name)
-	ll.Info("post name changed")
+func (p *postManager) processPostDelete(filename string) {
+	ll := log.KV("post.name", filename)
+	ll.Info("post deleted")
 
-	post, ok := p.DeletePostWithFilename(ev.Name)
+	post, ok := p.DeletePostWithFilename(filename)
 
 	if !ok {
 		ll.Error("unknown post, ignoring the deletion")
@@ -254,43 +624,110 @@ func (p *postManager) processPostDelete(ev *fsnotify.FileEvent) {
 	return
 }
 
-func (p *postManager) processPostCreate(ev *fsnotify.FileEvent) {
-	ll := log.KV("post.name", ev.Name)
+func (p *postManager) processPostCreate(filename string) error {
+	ll := log.KV("post.name", filename)
 	ll.Info("new post detected")
-	err := p.loadFromFile(ev.Name)
+	err := p.loadFromFile(filename)
 	if err != nil {
-		ll.Err(err).Error("can't load new post")
-		return
+		return fmt.Errorf("loading new post, %v", err)
 	}
 	ll.Info("new post has been assimilated")
+
+	cur, ok := p.postWithFilename(filename)
+	if !ok {
+		return nil
+	}
+
+	// Posts of a renamed directory come back all at once
+	var moved *post
+	p.mu.Lock()
+	pending := p.renamed[:0]
+	for _, renamed := range p.renamed {
+		switch {
+		case time.Since(renamed.at) > renameWindow:
+			// Was deleted rather than renamed, forget it
+		case moved == nil && cur.Title == renamed.post.Title && cur.Date.Equal(renamed.post.Date):
+			moved = renamed.post
+		default:
+			pending = append(pending, renamed)
+		}
+	}
+	p.renamed = pending
+	p.mu.Unlock()
+
+	if moved != nil {
+		p.recordMove(moved, cur)
+	}
+	return nil
 }
 
-func (p *postManager) processPostModify(ev *fsnotify.FileEvent) {
-	ll := log.KV("post.name", ev.Name)
+// processPostModify reloads the post in `filename`. The previous version is
+// kept until the new one loads.
+func (p *postManager) processPostModify(filename string) error {
+	ll := log.KV("post.name", filename)
 	ll.Info("modified post detected")
 
-	post, ok := p.DeletePostWithFilename(ev.Name)
+	post, ok := p.postWithFilename(filename)
 
 	if ok {
 		ll = ll.KV("post.title", post.Title)
 		ll.Info("reloading post")
 	}
 
-	if err := p.loadFromFile(ev.Name); err != nil {
-		ll.Err(err).Error("couldn't load modified post")
+	if err := p.loadFromFile(filename); err != nil {
+		return fmt.Errorf("loading modified post, %v", err)
+	}
+
+	// A new slug moves the post
+	if cur, found := p.postWithFilename(filename); ok && found {
+		p.recordMove(post, cur)
+	}
+	return nil
+}
+
+// postWithFilename finds the post loaded from `filename`.
+func (p *postManager) postWithFilename(filename string) (*post, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, post := range p.posts {
+		if post.filename == filename {
+			return post, true
+		}
+	}
+	return nil, false
+}
+
+// postsUnder lists the posts loaded from files under `dirname`.
+func (p *postManager) postsUnder(dirname string) []*post {
+	prefix := dirname + string(os.PathSeparator)
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var posts []*post
+	for _, post := range p.posts {
+		if strings.HasPrefix(post.filename, prefix) {
+			posts = append(posts, post)
+		}
 	}
+	return posts
 }
 
 func (p *postManager) loadFromFile(filename string) error {
-	post, err := newPostFromFile(filename)
+	highlightCode := p.brog.Config.Highlighting == serverHighlighting
+	post, err := newPostFromFile(filename, highlightCode)
 	if err != nil {
 		return fmt.Errorf("loading post from file '%s', %v", filename, err)
 	}
+	if bundle, ok := p.bundleOf(filename); ok {
+		post.bundle = bundle
+	}
+	post.setID(p.path)
 
 	p.SetPost(post)
 
 	if post.Invisible {
 		log.KV("post.title", post.Title).Info("post is invisible!")
+	} else if post.Date.After(p.now()) {
+		log.KV("post.title", post.Title).KV("post.date", post.Date).Info("post is scheduled")
 	}
 	return nil
 }
@@ -1,16 +1,32 @@
 package brogger
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
 
+func SetUpPostManager() *postManager {
+	search := newSearchIndex()
+	return &postManager{
+		permalink:   DefaultPermalink,
+		posts:       make(map[string]*post),
+		search:      search,
+		related:     newRelatedIndex(search),
+		byPermalink: make(map[string]*post),
+		byAlias:     make(map[string]*post),
+		now:         time.Now,
+	}
+}
+
 func TestNewPostFromFile(t *testing.T) {
 	/* Perhaps this should be a file in a test/ directory */
 	_ = os.Chdir("base")
 	defer func() { _ = os.Chdir("..") }()
-	post, err := newPostFromFile("posts" + string(os.PathSeparator) + "sample.md")
+	post, err := newPostFromFile("posts"+string(os.PathSeparator)+"sample.md", false)
 	if err != nil {
 		t.Errorf("Can't read post because of error: %v", err)
 	}
@@ -32,10 +48,13 @@ func TestNewPostFromFile(t *testing.T) {
 	if post.Language != "en" {
 		t.Error("newPostFromFile doesn't read language properly. Got", post.Language)
 	}
+	if len(post.Tags) != 2 || post.Tags[0] != "brog" || post.Tags[1] != "hello" {
+		t.Error("newPostFromFile doesn't read tags properly. Got", post.Tags)
+	}
 }
 
 func TestGetAllPosts(t *testing.T) {
-	pmgr, err := startPostManager(SetUpDefaultBrog(), "base"+string(os.PathSeparator)+DefaultPostPath)
+	pmgr, err := startPostManager(SetUpDefaultBrog(), "base"+string(os.PathSeparator)+DefaultPostPath, DefaultPermalink)
 	if err != nil {
 		t.Errorf("Error encountered starting post manager: %v", err)
 	}
@@ -44,3 +63,147 @@ func TestGetAllPosts(t *testing.T) {
 		t.Error("Invisible posts returned by Get All Posts")
 	}
 }
+
+func TestPlainText(t *testing.T) {
+	got := plainText("<h1>Hello!!</h1>\n<ul><li>a &amp; b</li><li>c</li></ul>")
+	if got != "Hello!! a & b c" {
+		t.Error("plainText doesn't strip markup properly. Got", got)
+	}
+
+	got = plainText(`<p>Uns<em>peak</em>able <code><span class="kw">func</span><span class="p">()</span></code><br/>end</p>`)
+	if got != "Unspeakable func() end" {
+		t.Error("plainText splits words at inline markup. Got", got)
+	}
+}
+
+func TestIsPublished(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	if !(&post{Date: past}).isPublished(now) {
+		t.Error("Post dated in the past should be published")
+	}
+	if (&post{Date: past, Invisible: true}).isPublished(now) {
+		t.Error("Invisible post should not be published")
+	}
+	if (&post{Date: future}).isPublished(now) {
+		t.Error("Post dated in the future should not be published yet")
+	}
+	if (&post{Date: past, Expires: &past}).isPublished(now) {
+		t.Error("Expired post should not be published")
+	}
+	if !(&post{Date: past, Expires: &future}).isPublished(now) {
+		t.Error("Post expiring in the future should be published")
+	}
+}
+
+func TestScheduledPublication(t *testing.T) {
+	now := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	publish := now.Add(time.Hour)
+	expire := publish.Add(time.Hour)
+
+	pmgr := SetUpPostManager()
+	pmgr.now = func() time.Time { return now }
+	defer func() { _ = pmgr.Close() }()
+	pmgr.SetPost(&post{id: "scheduled", Date: publish, Expires: &expire})
+
+	if len(pmgr.GetAllPosts()) != 0 {
+		t.Fatal("Scheduled post is visible before its date")
+	}
+	if _, ok := pmgr.GetPost("scheduled"); ok {
+		t.Fatal("Scheduled post can be read before its date")
+	}
+	if pmgr.schedule == nil {
+		t.Fatal("Publication of the scheduled post wasn't scheduled")
+	}
+
+	// What the schedule does once its time comes
+	now = publish
+	pmgr.sortPosts()
+	if len(pmgr.GetAllPosts()) != 1 {
+		t.Fatal("Scheduled post didn't appear at its date")
+	}
+	if pmgr.schedule == nil {
+		t.Fatal("Expiry of the published post wasn't scheduled")
+	}
+
+	now = expire
+	pmgr.sortPosts()
+	if len(pmgr.GetAllPosts()) != 0 {
+		t.Fatal("Post didn't disappear when it expired")
+	}
+	if pmgr.schedule != nil {
+		t.Error("Schedule still armed with nothing left to publish")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	p := &post{Content: strings.Repeat("<p>word</p>\n", 401)}
+	p.text = plainText(p.Content)
+	p.summarize()
+	if p.WordCount != 401 || p.ReadingTime != 3 {
+		t.Errorf("Expected 401 words read in 3 minutes, got %d words in %d", p.WordCount, p.ReadingTime)
+	}
+	if want := strings.Repeat("word ", excerptWords-1) + "word&hellip;"; p.Excerpt != want {
+		t.Error("Excerpt isn't the first words of the content. Got", p.Excerpt)
+	}
+
+	p = &post{Content: "<p>Intro &amp; more</p>\n<!--more-->\n<p>Rest</p>"}
+	p.text = plainText(p.Content)
+	p.summarize()
+	if p.Excerpt != "Intro &amp; more" {
+		t.Error("Excerpt doesn't stop at the more marker. Got", p.Excerpt)
+	}
+
+	p.Abstract = "Written by hand"
+	p.summarize()
+	if p.Excerpt != p.Abstract {
+		t.Error("Excerpt isn't the abstract. Got", p.Excerpt)
+	}
+}
+
+func TestLoadNestedPosts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brog-nested")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"2013/hello.md":      "{\"title\": \"Hello 2013\"}\nHello\n",
+		"2014/hello.md":      "{\"title\": \"Hello 2014\"}\nHello\n",
+		"docs/install.md":    "{\"title\": \"Install\"}\nInstall\n",
+		"docs/setup/unix.md": "{\"title\": \"Unix\", \"slug\": \"linux\"}\nUnix\n",
+	}
+	for name, content := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pmgr, err := newPostManager(SetUpDefaultBrog(), dir, pagePermalink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, permalink := range map[string]string{
+		"2013/hello":      "/pages/2013/hello",
+		"2014/hello":      "/pages/2014/hello",
+		"docs/install":    "/pages/docs/install",
+		"docs/setup/unix": "/pages/docs/setup/linux",
+	} {
+		p, ok := pmgr.GetPost(id)
+		if !ok {
+			t.Errorf("No post with ID '%s'", id)
+			continue
+		}
+		if p.Permalink() != permalink {
+			t.Errorf("Post '%s' is at '%s', expected '%s'", id, p.Permalink(), permalink)
+		}
+		if found, ok := pmgr.GetPostAt(permalink); !ok || found != p {
+			t.Errorf("Post '%s' isn't found at its permalink", id)
+		}
+	}
+}
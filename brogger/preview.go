@@ -0,0 +1,122 @@
+package brogger
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aybabtme/log"
+)
+
+// previewPrefix is where signed previews are served, in the form
+// `/preview/<expiry>/<signature>/<post id>`.
+const previewPrefix = "/preview/"
+
+// DefaultPreviewTTL is how long a preview URL is valid when no duration is
+// given.
+const DefaultPreviewTTL = 7 * 24 * time.Hour
+
+// newPreviewSecret generates a random secret to sign preview URLs with.
+func newPreviewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generating preview secret, %v", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// signPreview is the signature of a preview of post `id` valid until
+// `expiry`, a unix timestamp.
+func signPreview(secret, id string, expiry int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%s\n%d", id, expiry)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// previewPath is the path of a preview of post `id` valid until `expiry`.
+func previewPath(secret, id string, expiry time.Time) string {
+	sig := signPreview(secret, id, expiry.Unix())
+	return fmt.Sprintf("%s%d/%s/%s", previewPrefix, expiry.Unix(), sig, id)
+}
+
+// verifyPreview checks that `urlpath` is a valid preview path at `now`, and
+// returns the ID of the post it previews.
+func verifyPreview(secret, urlpath string, now time.Time) (string, error) {
+	if secret == "" {
+		return "", fmt.Errorf("previews are disabled, no secret is configured")
+	}
+
+	// IDs of posts in subdirectories have slashes too
+	parts := strings.SplitN(strings.TrimPrefix(urlpath, previewPrefix), "/", 3)
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed preview path")
+	}
+
+	expiry, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("malformed preview expiry, %v", err)
+	}
+	sig, id := parts[1], parts[2]
+
+	want := signPreview(secret, id, expiry)
+	if !hmac.Equal([]byte(sig), []byte(want)) {
+		return "", fmt.Errorf("invalid preview signature")
+	}
+	if now.Unix() > expiry {
+		return "", fmt.Errorf("preview expired at %v", time.Unix(expiry, 0))
+	}
+	return id, nil
+}
+
+// PreviewURL creates a URL to share the post with ID `id`, published or not,
+// until `ttl` has elapsed.
+func (b *Brog) PreviewURL(id string, ttl time.Duration) (string, error) {
+	if b.Config.PreviewSecret == "" {
+		return "", fmt.Errorf("no preview secret in config file '%s'", ConfigFilename)
+	}
+
+	if err := b.loadContent(); err != nil {
+		return "", fmt.Errorf("loading content, %v", err)
+	}
+	if _, ok := b.postMngr.GetPostOrDraft(id); !ok {
+		return "", fmt.Errorf("no post with ID '%s'", id)
+	}
+
+	expiry := time.Now().Add(ttl)
+	return b.absURL(previewPath(b.Config.PreviewSecret, id, expiry)), nil
+}
+
+func (b *Brog) previewFunc(rw http.ResponseWriter, req *http.Request) {
+
+	lang, _ := b.extractLanguage(req)
+
+	reqPath := strings.SplitN(req.RequestURI, "?", 2)[0]
+	postID, err := verifyPreview(b.Config.PreviewSecret, reqPath, time.Now())
+	if err != nil {
+		log.Err(err).KV("req.uri", req.RequestURI).Info("refused preview")
+		b.notFound(rw, req)
+		return
+	}
+
+	post, ok := b.postMngr.GetPostOrDraft(postID)
+	if !ok {
+		b.notFound(rw, req)
+		return
+	}
+
+	data := b.postContent(lang, b.postMngr, post)
+
+	// Previews are for one reviewer, not for caches or search engines
+	rw.Header().Set("Cache-Control", "private, no-store")
+	rw.Header().Set("X-Robots-Tag", "noindex")
+
+	if err := b.render(rw, req, b.tmplMngr.DoWithPost, data); err != nil {
+		log.Err(err).KV("post.id", postID).Error("couldn't render preview template")
+	}
+}
@@ -0,0 +1,62 @@
+package brogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerifyPreview(t *testing.T) {
+	now := time.Now()
+	path := previewPath("secret", "my_draft", now.Add(time.Hour))
+
+	id, err := verifyPreview("secret", path, now)
+	if err != nil {
+		t.Fatalf("Valid preview refused: %v", err)
+	}
+	if id != "my_draft" {
+		t.Error("Preview of wrong post. Got", id)
+	}
+
+	if _, err := verifyPreview("secret", path, now.Add(2*time.Hour)); err == nil {
+		t.Error("Expired preview accepted")
+	}
+	if _, err := verifyPreview("other secret", path, now); err == nil {
+		t.Error("Preview signed with another secret accepted")
+	}
+	if _, err := verifyPreview("", previewPath("", "my_draft", now.Add(time.Hour)), now); err == nil {
+		t.Error("Preview accepted without a secret")
+	}
+
+	nested := previewPath("secret", "2014/my_draft", now.Add(time.Hour))
+	if id, err := verifyPreview("secret", nested, now); err != nil || id != "2014/my_draft" {
+		t.Errorf("Preview of post in a subdirectory refused. Got %q, %v", id, err)
+	}
+
+	forged := previewPath("secret", "other_draft", now.Add(time.Hour))
+	forged = forged[:len(forged)-len("other_draft")] + "my_draft"
+	if _, err := verifyPreview("secret", forged, now); err == nil {
+		t.Error("Preview accepted for a post it wasn't signed for")
+	}
+}
+
+func TestDraftsInDevel(t *testing.T) {
+	b := SetUpDefaultBrog()
+	b.postMngr = SetUpPostManager()
+	b.pageMngr = SetUpPostManager()
+	b.postMngr.SetPost(&post{id: "draft", Invisible: true})
+
+	if _, ok := b.getPost(b.postMngr, "draft"); ok {
+		t.Error("Draft served while drafts aren't previewed")
+	}
+	if data, _ := b.indexContent("", 1); len(data.Posts) != 0 {
+		t.Error("Draft listed while drafts aren't previewed")
+	}
+
+	b.drafts = true
+	if _, ok := b.getPost(b.postMngr, "draft"); !ok {
+		t.Error("Draft not served while drafts are previewed")
+	}
+	if data, _ := b.indexContent("", 1); len(data.Posts) != 1 || !data.Posts[0].IsDraft() {
+		t.Error("Draft not listed while drafts are previewed")
+	}
+}
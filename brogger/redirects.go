@@ -0,0 +1,194 @@
+package brogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aybabtme/log"
+)
+
+// renameWindow is how long after a post file disappears a new file is
+// considered to be that post, renamed.
+const renameWindow = 2 * time.Second
+
+// redirects remembers the URLs at which posts used to live, persisted in a
+// file so that old links keep working across restarts and builds.
+type redirects struct {
+	filename string
+
+	mu    sync.RWMutex
+	paths map[string]string // New URL path, by old URL path
+}
+
+// loadRedirects reads the redirects persisted in `filename`, if any.
+func loadRedirects(filename string) (*redirects, error) {
+	r := &redirects{
+		filename: filename,
+		paths:    make(map[string]string),
+	}
+	if !fileExists(filename) {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading redirects file '%s', %v", filename, err)
+	}
+	if err := json.Unmarshal(data, &r.paths); err != nil {
+		return nil, fmt.Errorf("decoding redirects file '%s', %v", filename, err)
+	}
+	return r, nil
+}
+
+// Get is where to redirect requests for `urlpath`, or false if nothing moved
+// from there.
+func (r *redirects) Get(urlpath string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	to, ok := r.paths[permalinkKey(urlpath)]
+	return to, ok
+}
+
+// All lists the old URL paths that redirect somewhere, sorted.
+func (r *redirects) All() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	froms := make([]string, 0, len(r.paths))
+	for from := range r.paths {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+	return froms
+}
+
+// Add redirects `from` to `to` and persists it. Older URLs that redirected to
+// `from` now go straight to `to`, and `to` stops redirecting since something
+// lives there again.
+func (r *redirects) Add(from, to string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fromKey, toKey := permalinkKey(from), permalinkKey(to)
+	if fromKey == toKey {
+		return nil
+	}
+	for old, cur := range r.paths {
+		if permalinkKey(cur) == fromKey {
+			r.paths[old] = to
+		}
+	}
+	delete(r.paths, toKey)
+	r.paths[fromKey] = to
+
+	data, err := json.MarshalIndent(r.paths, "", strings.Repeat(" ", JSONIndentCount))
+	if err != nil {
+		return fmt.Errorf("encoding redirects, %v", err)
+	}
+	if err := ioutil.WriteFile(r.filename, append(data, '\n'), 0640); err != nil {
+		return fmt.Errorf("writing redirects file '%s', %v", r.filename, err)
+	}
+	return nil
+}
+
+// aliasKey normalizes an alias of a post into a URL path.
+func aliasKey(alias string) string {
+	alias = strings.TrimSpace(alias)
+	if !strings.HasPrefix(alias, "/") {
+		alias = "/" + alias
+	}
+	return permalinkKey(alias)
+}
+
+// redirectTarget is where a post that used to live at `urlpath` moved, either
+// because the post lists `urlpath` in its aliases or because it was renamed.
+func (b *Brog) redirectTarget(urlpath string) (string, bool) {
+	for _, mngr := range []*postManager{b.postMngr, b.pageMngr} {
+		if post, ok := mngr.GetPostWithAlias(urlpath); ok && (b.drafts || !post.IsDraft()) {
+			return post.Permalink(), true
+		}
+	}
+	return b.redirects.Get(urlpath)
+}
+
+// redirectMoved answers with a permanent redirect if a post moved away from
+// `urlpath`. It returns false if nothing did.
+func (b *Brog) redirectMoved(rw http.ResponseWriter, req *http.Request, urlpath string) bool {
+	to, ok := b.redirectTarget(urlpath)
+	if !ok {
+		return false
+	}
+	http.Redirect(rw, req, to, http.StatusMovedPermanently)
+	return true
+}
+
+// redirectPage replaces HTTP redirects in static builds, which can't answer
+// with a status code.
+var redirectPage = template.Must(template.New("redirect").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.}}</title>
+<link rel="canonical" href="{{.}}">
+<meta name="robots" content="noindex">
+<meta http-equiv="refresh" content="0; url={{.}}">
+</head>
+<body><a href="{{.}}">{{.}}</a></body>
+</html>
+`))
+
+// buildRedirects writes a redirect page at every alias of a post and every
+// URL a post was renamed from, unless something else lives there now.
+func (b *Brog) buildRedirects(outdir string) error {
+	froms := b.redirects.All()
+	for _, mngr := range []*postManager{b.postMngr, b.pageMngr} {
+		for _, post := range mngr.GetAllPosts() {
+			for _, alias := range post.Aliases {
+				froms = append(froms, aliasKey(alias))
+			}
+		}
+	}
+
+	for _, from := range froms {
+		if from == "/" {
+			continue
+		}
+		if _, ok := b.getPostAt(b.postMngr, from); ok {
+			continue
+		}
+		if _, ok := b.getPostAt(b.pageMngr, from); ok {
+			continue
+		}
+		to, ok := b.redirectTarget(from)
+		if !ok {
+			continue
+		}
+
+		filename, err := urlFilename(outdir, from)
+		if err != nil {
+			return err
+		}
+		buf := bytes.NewBuffer(nil)
+		if err := redirectPage.Execute(buf, to); err != nil {
+			log.Err(err).KV("redirect.from", from).Error("couldn't render redirect page")
+			return fmt.Errorf("rendering redirect page '%s', %v", filename, err)
+		}
+		if err := writeFile(filename, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package brogger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRedirectsPersist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brog-redirects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, RedirectsFilename)
+
+	r, err := loadRedirects(filename)
+	if err != nil {
+		t.Fatalf("Missing redirects file should be empty, got %v", err)
+	}
+	if err := r.Add("/posts/old", "/posts/new/"); err != nil {
+		t.Fatalf("Can't add redirect: %v", err)
+	}
+	if err := r.Add("/posts/new/", "/posts/newer"); err != nil {
+		t.Fatalf("Can't add redirect: %v", err)
+	}
+
+	r, err = loadRedirects(filename)
+	if err != nil {
+		t.Fatalf("Can't reload redirects: %v", err)
+	}
+	if to, ok := r.Get("/posts/old/"); !ok || to != "/posts/newer" {
+		t.Error("Chained redirect doesn't go straight to the last URL. Got", to)
+	}
+
+	// Moving back where a post used to be stops redirecting from there
+	if err := r.Add("/posts/newer", "/posts/old"); err != nil {
+		t.Fatalf("Can't add redirect: %v", err)
+	}
+	if to, ok := r.Get("/posts/old"); ok {
+		t.Error("URL with a post still redirects to", to)
+	}
+	if to, ok := r.Get("/posts/new"); !ok || to != "/posts/old" {
+		t.Error("Redirect wasn't updated to the latest URL. Got", to)
+	}
+}
+
+func TestRedirectTargetOfAlias(t *testing.T) {
+	b := SetUpDefaultBrog()
+	b.postMngr = SetUpPostManager()
+	b.pageMngr = SetUpPostManager()
+
+	p := &post{id: "hello", Aliases: []string{"2014/hello.html", "/old/hello/"}}
+	b.postMngr.SetPost(p)
+
+	for _, urlpath := range []string{"/2014/hello.html", "/old/hello"} {
+		if to, ok := b.redirectTarget(urlpath); !ok || to != "/posts/hello" {
+			t.Errorf("Alias '%s' doesn't redirect to the post. Got %q", urlpath, to)
+		}
+	}
+
+	b.postMngr.DeletePost(p)
+	if _, ok := b.redirectTarget("/old/hello"); ok {
+		t.Error("Alias of a deleted post still redirects")
+	}
+}
@@ -0,0 +1,276 @@
+package brogger
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	maxRelatedPosts = 5
+	// Related posts kept per post, more than shown so that some can be
+	// skipped while they aren't published.
+	relatedCandidates = 3 * maxRelatedPosts
+	// How much sharing all their tags weights against having the same text.
+	relatedTagWeight = 1.0
+)
+
+// relatedIndex caches the posts most related to each post, by similarity of
+// their tags and of their text. It scores posts with the term frequencies of
+// a searchIndex, which must be updated first. Reading it with get is safe for
+// concurrent use, changing it isn't: the postManager locks it along with its
+// posts.
+//
+// When a post changes, only that post is scored against the others. It's put
+// back in the lists of the other posts with its new score, and only the lists
+// it falls out of while they were full are computed again by refresh: the
+// post that should take its place isn't known.
+type relatedIndex struct {
+	search *searchIndex
+	lists  map[string][]scoredPost // Most related first, by post ID
+	stale  map[string]bool         // Posts whose list is missing an entry
+}
+
+type scoredPost struct {
+	post  *post
+	score float64
+}
+
+func newRelatedIndex(search *searchIndex) *relatedIndex {
+	return &relatedIndex{
+		search: search,
+		lists:  make(map[string][]scoredPost),
+		stale:  make(map[string]bool),
+	}
+}
+
+// update scores `p` against the other posts, after it was added to the
+// search index.
+func (r *relatedIndex) update(p *post) {
+	id := p.GetID()
+	scores := r.similarities(p)
+	r.lists[id] = topScored(scores, r.search)
+	delete(r.stale, id)
+
+	for other, list := range r.lists {
+		if other == id || r.stale[other] {
+			continue
+		}
+		score, similar := scores[other]
+		full := len(list) == relatedCandidates
+		list, floor, ok := withoutPost(list, id)
+		switch {
+		case ok && full && (!similar || score < floor):
+			// Another post might be more related than `p` now
+			r.lists[other] = list
+			r.stale[other] = true
+		case similar:
+			r.lists[other] = insertScored(list, scoredPost{post: p, score: score})
+		default:
+			r.lists[other] = list
+		}
+	}
+}
+
+// remove forgets `id`, after it was removed from the search index.
+func (r *relatedIndex) remove(id string) {
+	delete(r.lists, id)
+	delete(r.stale, id)
+	for other, list := range r.lists {
+		full := len(list) == relatedCandidates
+		if list, _, ok := withoutPost(list, id); ok {
+			r.lists[other] = list
+			// The next most related post can take its place
+			r.stale[other] = r.stale[other] || full
+		}
+	}
+}
+
+// withoutPost takes `id` out of `list`, telling if it was in it along with
+// the lowest score the list had.
+func withoutPost(list []scoredPost, id string) ([]scoredPost, float64, bool) {
+	for i, related := range list {
+		if related.post.GetID() == id {
+			floor := list[len(list)-1].score
+			return append(list[:i:i], list[i+1:]...), floor, true
+		}
+	}
+	return list, 0, false
+}
+
+// refresh computes again the lists that are missing an entry, so that get
+// doesn't have to.
+func (r *relatedIndex) refresh() {
+	for id := range r.stale {
+		if doc, ok := r.search.docs[id]; ok {
+			r.lists[id] = topScored(r.similarities(doc.post), r.search)
+		} else {
+			delete(r.lists, id)
+		}
+		delete(r.stale, id)
+	}
+}
+
+// rebuild computes the lists of all the posts of the search index, once
+// they're all loaded.
+func (r *relatedIndex) rebuild() {
+	r.lists = make(map[string][]scoredPost, len(r.search.docs))
+	r.stale = make(map[string]bool)
+	for id, doc := range r.search.docs {
+		r.lists[id] = topScored(r.similarities(doc.post), r.search)
+	}
+}
+
+// get lists the posts most related to `p` that are published at `now`. It
+// only reads the index: the list of a post that isn't in it, or that's
+// stale, is computed without being kept.
+func (r *relatedIndex) get(p *post, now time.Time) []*post {
+	id := p.GetID()
+	list, ok := r.lists[id]
+	if !ok || r.stale[id] {
+		list = topScored(r.similarities(p), r.search)
+	}
+
+	var posts []*post
+	for _, related := range list {
+		if len(posts) == maxRelatedPosts {
+			break
+		}
+		if related.post.isPublished(now) {
+			posts = append(posts, related.post)
+		}
+	}
+	return posts
+}
+
+// similarities scores every post in the language of `p` that has something
+// in common with it: the TF-IDF cosine of their terms plus the share of
+// their tags they have in common.
+func (r *relatedIndex) similarities(p *post) map[string]float64 {
+	s := r.search
+	id := p.GetID()
+	doc, ok := s.docs[id]
+	if !ok {
+		return nil
+	}
+
+	n := float64(len(s.docs))
+	idf := func(term string) float64 {
+		return math.Log(1 + n/float64(len(s.postings[term])))
+	}
+	norm := func(doc *searchDoc) float64 {
+		sum := 0.0
+		for _, term := range doc.terms {
+			w := float64(s.postings[term][doc.post.GetID()]) * idf(term)
+			sum += w * w
+		}
+		return math.Sqrt(sum)
+	}
+
+	dots := make(map[string]float64)
+	for _, term := range doc.terms {
+		w := idf(term)
+		tf := float64(s.postings[term][id])
+		for other, otherTF := range s.postings[term] {
+			if other != id {
+				dots[other] += tf * w * float64(otherTF) * w
+			}
+		}
+	}
+
+	scores := make(map[string]float64)
+	docNorm := norm(doc)
+	for other, dot := range dots {
+		if otherNorm := norm(s.docs[other]); docNorm != 0 && otherNorm != 0 {
+			scores[other] = dot / (docNorm * otherNorm)
+		}
+	}
+	for other, otherDoc := range s.docs {
+		if other == id {
+			continue
+		}
+		if shared := sharedTags(p.Tags, otherDoc.post.Tags); shared != 0 {
+			scores[other] += relatedTagWeight * shared
+		}
+	}
+
+	for other := range scores {
+		if lang := s.docs[other].post.Language; p.Language != "" && lang != "" && lang != p.Language {
+			delete(scores, other)
+		}
+	}
+	return scores
+}
+
+// sharedTags is the share of all the tags of `a` and `b` they have in
+// common, from 0 to 1.
+func sharedTags(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, tag := range a {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			set[tag] = true
+		}
+	}
+	union := len(set)
+	common := 0
+	seen := make(map[string]bool, len(b))
+	for _, tag := range b {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		if set[tag] {
+			common++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
+
+// topScored keeps the best `relatedCandidates` of `scores`.
+func topScored(scores map[string]float64, s *searchIndex) []scoredPost {
+	list := make(scoredList, 0, len(scores))
+	for id, score := range scores {
+		list = append(list, scoredPost{post: s.docs[id].post, score: score})
+	}
+	sort.Sort(list)
+	if len(list) > relatedCandidates {
+		list = list[:relatedCandidates]
+	}
+	return list
+}
+
+// insertScored puts `related` in its place in `list`, if it's good enough.
+func insertScored(list []scoredPost, related scoredPost) []scoredPost {
+	i := sort.Search(len(list), func(i int) bool {
+		return scoredList{related, list[i]}.Less(0, 1)
+	})
+	if i == relatedCandidates {
+		return list
+	}
+	list = append(list, scoredPost{})
+	copy(list[i+1:], list[i:])
+	list[i] = related
+	if len(list) > relatedCandidates {
+		list = list[:relatedCandidates]
+	}
+	return list
+}
+
+type scoredList []scoredPost
+
+func (s scoredList) Len() int      { return len(s) }
+func (s scoredList) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s scoredList) Less(i, j int) bool {
+	if s[i].score != s[j].score {
+		return s[i].score > s[j].score
+	}
+	// Most recent first among equals
+	return s[i].post.Date.After(s[j].post.Date)
+}
@@ -0,0 +1,133 @@
+package brogger
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func SetUpRelatedPosts(pmgr *postManager) []*post {
+	posts := []*post{
+		{id: "gc", Title: "Tuning the garbage collector", text: "go garbage collector heap pauses tuning", Tags: []string{"go", "performance"}},
+		{id: "heap", Title: "Profiling the heap", text: "go heap profiling pprof allocations", Tags: []string{"go", "performance"}},
+		{id: "channels", Title: "Channels", text: "go channels goroutines select", Tags: []string{"go"}},
+		{id: "bread", Title: "Baking bread", text: "flour water salt yeast oven", Tags: []string{"cooking"}},
+	}
+	for _, p := range posts {
+		pmgr.SetPost(p)
+	}
+	return posts
+}
+
+func TestRelatedPosts(t *testing.T) {
+	pmgr := SetUpPostManager()
+	posts := SetUpRelatedPosts(pmgr)
+
+	related := pmgr.GetRelated(posts[0])
+	if len(related) != 2 || related[0].id != "heap" || related[1].id != "channels" {
+		t.Fatalf("Related posts aren't ranked by shared tags and terms. Got %v", related)
+	}
+	if related := pmgr.GetRelated(posts[3]); len(related) != 0 {
+		t.Error("Post with nothing in common has related posts. Got", related)
+	}
+
+	// Drafts aren't suggested
+	posts[1].Invisible = true
+	if related := pmgr.GetRelated(posts[0]); len(related) != 1 || related[0].id != "channels" {
+		t.Error("Related posts include a draft. Got", related)
+	}
+}
+
+func TestRelatedPostsIncremental(t *testing.T) {
+	pmgr := SetUpPostManager()
+	posts := SetUpRelatedPosts(pmgr)
+
+	// Computed before the changes, then patched
+	pmgr.GetRelated(posts[2])
+	pmgr.GetRelated(posts[3])
+
+	pmgr.SetPost(&post{id: "sourdough", Title: "Sourdough", text: "flour water salt starter oven", Tags: []string{"cooking"}, Date: time.Now()})
+	pmgr.DeletePost(posts[1])
+	if len(pmgr.related.stale) != 0 {
+		t.Error("Lists of related posts are left to compute while reading them")
+	}
+
+	if related := pmgr.GetRelated(posts[3]); len(related) != 1 || related[0].id != "sourdough" {
+		t.Error("New post wasn't added to the related posts of others. Got", related)
+	}
+	for _, related := range pmgr.GetRelated(posts[2]) {
+		if related.id == "heap" {
+			t.Error("Deleted post is still related to others")
+		}
+	}
+
+	// Patched lists match lists computed from scratch
+	fresh := newRelatedIndex(pmgr.search)
+	for _, p := range []*post{posts[0], posts[2], posts[3]} {
+		want := fresh.get(p, time.Now())
+		got := pmgr.GetRelated(p)
+		if len(want) != len(got) {
+			t.Errorf("Post %s has %d related posts, expected %d", p.id, len(got), len(want))
+			continue
+		}
+		for i := range want {
+			if want[i] != got[i] {
+				t.Errorf("Post %s has related post %s at %d, expected %s", p.id, got[i].id, i, want[i].id)
+			}
+		}
+	}
+}
+
+func TestRelatedPostsFullLists(t *testing.T) {
+	pmgr := SetUpPostManager()
+	date := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	var posts []*post
+	for i := 0; i < relatedCandidates+5; i++ {
+		// Related by their tags alone, whose scores don't change as posts
+		// come and go
+		tags := []string{"go"}
+		if i%2 == 0 {
+			tags = append(tags, "even")
+		}
+		if i%3 == 0 {
+			tags = append(tags, "third")
+		}
+		p := &post{id: fmt.Sprintf("go%d", i), text: fmt.Sprintf("word%d", i), Tags: tags, Date: date.Add(time.Duration(i) * time.Hour)}
+		posts = append(posts, p)
+		pmgr.SetPost(p)
+	}
+
+	check := func(when string) {
+		for id, doc := range pmgr.search.docs {
+			want := topScored(pmgr.related.similarities(doc.post), pmgr.search)
+			got := pmgr.related.lists[id]
+			if fmt.Sprint(want) != fmt.Sprint(got) {
+				t.Errorf("%s, post %s has related posts %v, expected %v", when, id, got, want)
+			}
+		}
+		if len(pmgr.related.stale) != 0 {
+			t.Errorf("%s, lists of related posts are left to compute", when)
+		}
+	}
+	check("Once loaded")
+
+	// Falls out of the full lists it was in
+	old := posts[0]
+	pmgr.SetPost(&post{id: old.id, text: old.text, Tags: []string{"cooking"}, Date: old.Date})
+	check("Once a post has nothing in common anymore")
+	pmgr.SetPost(&post{id: old.id, text: old.text, Tags: []string{"go"}, Date: old.Date})
+	check("Once a post is less related")
+	pmgr.SetPost(old)
+	check("Once a post is related again")
+	pmgr.DeletePost(posts[6])
+	check("Once a post is deleted")
+}
+
+func TestSharedTags(t *testing.T) {
+	if shared := sharedTags([]string{"go", "web"}, []string{"go", "db", "go"}); shared != 1.0/3 {
+		t.Error("Expected a third of the tags to be shared, got", shared)
+	}
+	if shared := sharedTags(nil, []string{" "}); shared != 0 {
+		t.Error("Blank tags are shared. Got", shared)
+	}
+}
@@ -0,0 +1,287 @@
+package brogger
+
+import (
+	"encoding/json"
+	"html"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/aybabtme/log"
+)
+
+const (
+	// Okapi BM25 parameters
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// How much more a term weights when found in these fields than in the
+	// text of a post.
+	titleWeight    = 3
+	abstractWeight = 2
+
+	maxSearchResults = 50
+	snippetWords     = 30
+	snippetLead      = 8
+)
+
+// searchIndex is an inverted index of the posts of a postManager. It isn't
+// safe for concurrent use, the postManager locks it along with its posts.
+type searchIndex struct {
+	docs     map[string]*searchDoc     // Indexed posts, by post ID
+	postings map[string]map[string]int // Term frequencies, by term then post ID
+	totalLen int                       // Sum of the length of all docs
+}
+
+type searchDoc struct {
+	post   *post
+	terms  []string // Distinct terms of the post
+	length int      // Weighted count of terms
+}
+
+// searchResult is a post matching a search query.
+type searchResult struct {
+	Post    *post
+	Score   float64
+	Snippet string // HTML excerpt of the post with the matching terms marked
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		docs:     make(map[string]*searchDoc),
+		postings: make(map[string]map[string]int),
+	}
+}
+
+// tokenize splits `text` in lower case terms.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func (s *searchIndex) add(p *post) {
+	id := p.GetID()
+	s.remove(id)
+
+	freqs := make(map[string]int)
+	length := 0
+	count := func(text string, weight int) {
+		for _, term := range tokenize(text) {
+			freqs[term] += weight
+			length += weight
+		}
+	}
+	count(p.Title, titleWeight)
+	count(p.Abstract, abstractWeight)
+	count(p.text, 1)
+
+	terms := make([]string, 0, len(freqs))
+	for term, freq := range freqs {
+		docs, ok := s.postings[term]
+		if !ok {
+			docs = make(map[string]int)
+			s.postings[term] = docs
+		}
+		docs[id] = freq
+		terms = append(terms, term)
+	}
+	s.docs[id] = &searchDoc{post: p, terms: terms, length: length}
+	s.totalLen += length
+}
+
+func (s *searchIndex) remove(id string) {
+	doc, ok := s.docs[id]
+	if !ok {
+		return
+	}
+	for _, term := range doc.terms {
+		docs := s.postings[term]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(s.postings, term)
+		}
+	}
+	s.totalLen -= doc.length
+	delete(s.docs, id)
+}
+
+// search ranks the visible posts in `lang` matching any term of `query`
+// using BM25.
+func (s *searchIndex) search(query, lang string) []searchResult {
+	terms := tokenize(query)
+	if len(terms) == 0 || len(s.docs) == 0 {
+		return nil
+	}
+
+	n := float64(len(s.docs))
+	avgLen := float64(s.totalLen) / n
+
+	scores := make(map[string]float64)
+	for _, term := range terms {
+		docs := s.postings[term]
+		df := float64(len(docs))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, freq := range docs {
+			tf := float64(freq)
+			norm := 1 - bm25B + bm25B*float64(s.docs[id].length)/avgLen
+			scores[id] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	now := time.Now()
+	var results []searchResult
+	for id, score := range scores {
+		p := s.docs[id].post
+		if !p.isPublished(now) || (lang != "" && p.Language != lang) {
+			continue
+		}
+		results = append(results, searchResult{Post: p, Score: score})
+	}
+	sort.Sort(resultList(results))
+
+	if len(results) > maxSearchResults {
+		results = results[:maxSearchResults]
+	}
+	for i := range results {
+		results[i].Snippet = snippet(results[i].Post.text, terms)
+	}
+	return results
+}
+
+type resultList []searchResult
+
+func (r resultList) Len() int      { return len(r) }
+func (r resultList) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r resultList) Less(i, j int) bool {
+	if r[i].Score != r[j].Score {
+		return r[i].Score > r[j].Score
+	}
+	// Most recent first among equals
+	return r[i].Post.Date.After(r[j].Post.Date)
+}
+
+// snippet excerpts `text` around the first word matching one of `terms`,
+// marking the matching words. The result is escaped HTML.
+func snippet(text string, terms []string) string {
+	isTerm := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		isTerm[term] = true
+	}
+	matches := func(word string) bool {
+		for _, term := range tokenize(word) {
+			if isTerm[term] {
+				return true
+			}
+		}
+		return false
+	}
+
+	words := strings.Fields(text)
+	start := 0
+	for i, word := range words {
+		if matches(word) {
+			start = i - snippetLead
+			break
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetWords
+	if end > len(words) {
+		end = len(words)
+	}
+
+	buf := make([]string, 0, end-start+2)
+	if start > 0 {
+		buf = append(buf, "&hellip;")
+	}
+	for _, word := range words[start:end] {
+		if matches(word) {
+			buf = append(buf, "<mark>"+html.EscapeString(word)+"</mark>")
+		} else {
+			buf = append(buf, html.EscapeString(word))
+		}
+	}
+	if end < len(words) {
+		buf = append(buf, "&hellip;")
+	}
+	return strings.Join(buf, " ")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// HandlerFuncs
+////////////////////////////////////////////////////////////////////////////////
+
+// searchLanguage is the language to search in, taken from the `lang` query
+// parameter or the language cookie. Search queries don't fit the language
+// in query scheme of the other pages.
+func (b *Brog) searchLanguage(req *http.Request) string {
+	if !b.Config.Multilingual {
+		return ""
+	}
+	lang := req.URL.Query().Get("lang")
+	if cookie, err := req.Cookie("lang"); lang == "" && err == nil {
+		lang = cookie.Value
+	}
+	for _, val := range b.Config.Languages {
+		if lang == val {
+			return lang
+		}
+	}
+	return ""
+}
+
+func (b *Brog) searchFunc(rw http.ResponseWriter, req *http.Request) {
+	lang := b.searchLanguage(req)
+	query := req.URL.Query().Get("q")
+
+	data := b.baseContent(lang)
+	data.Query = query
+	data.Results = b.postMngr.Search(query, lang)
+
+	if err := b.render(rw, req, b.tmplMngr.DoWithSearch, data); err != nil {
+		log.Err(err).KV("search.query", query).Error("couldn't render search template")
+	}
+}
+
+type jsonSearchResult struct {
+	ID       string    `json:"id"`
+	URL      string    `json:"url"`
+	Title    string    `json:"title"`
+	Date     time.Time `json:"date"`
+	Author   string    `json:"author"`
+	Abstract string    `json:"abstract"`
+	Language string    `json:"language"`
+	Score    float64   `json:"score"`
+	Snippet  string    `json:"snippet"`
+}
+
+func (b *Brog) searchJSONFunc(rw http.ResponseWriter, req *http.Request) {
+	lang := b.searchLanguage(req)
+	query := req.URL.Query().Get("q")
+
+	results := []jsonSearchResult{}
+	for _, res := range b.postMngr.Search(query, lang) {
+		results = append(results, jsonSearchResult{
+			ID:       res.Post.GetID(),
+			URL:      res.Post.Permalink(),
+			Title:    res.Post.Title,
+			Date:     res.Post.Date,
+			Author:   res.Post.Author,
+			Abstract: res.Post.Abstract,
+			Language: res.Post.Language,
+			Score:    res.Score,
+			Snippet:  res.Snippet,
+		})
+	}
+
+	rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(rw).Encode(results); err != nil {
+		log.Err(err).KV("search.query", query).Error("couldn't encode search results")
+	}
+}
@@ -0,0 +1,49 @@
+package brogger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchIndex(t *testing.T) {
+	idx := newSearchIndex()
+	idx.add(&post{id: "go", Title: "Learning Go", Language: "en", text: "Go is a language. We like writing Go."})
+	idx.add(&post{id: "rust", Title: "Learning Rust", Language: "en", text: "Rust is a language too, but not Go."})
+	idx.add(&post{id: "draft", Title: "Go draft", Language: "en", Invisible: true, text: "Go go go"})
+	idx.add(&post{id: "fr", Title: "Apprendre Go", Language: "fr", text: "Go est un langage."})
+
+	results := idx.search("go", "en")
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 visible english results, got %d", len(results))
+	}
+	if results[0].Post.id != "go" {
+		t.Error("Post about Go should rank first. Got", results[0].Post.id)
+	}
+	if !strings.Contains(results[0].Snippet, "<mark>Go</mark>") {
+		t.Error("Snippet doesn't mark matching terms. Got", results[0].Snippet)
+	}
+
+	if results := idx.search("GO", ""); len(results) != 3 {
+		t.Error("Search should be case insensitive and cover all languages. Got", len(results))
+	}
+
+	idx.remove("go")
+	if results := idx.search("writing", ""); len(results) != 0 {
+		t.Error("Removed post is still found")
+	}
+	idx.add(&post{id: "rust", Title: "Learning Rust", Language: "en", text: "Rewritten."})
+	if results := idx.search("language", ""); len(results) != 0 {
+		t.Error("Re-indexed post still matches its old text")
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	text := strings.Repeat("filler ", 20) + "needle <b>" + strings.Repeat(" filler", 40)
+	got := snippet(text, []string{"needle"})
+	if !strings.HasPrefix(got, "&hellip;") || !strings.HasSuffix(got, "&hellip;") {
+		t.Error("Truncated snippet should be wrapped in ellipsis. Got", got)
+	}
+	if !strings.Contains(got, "<mark>needle</mark> &lt;b&gt;") {
+		t.Error("Snippet should mark the match and escape the text. Got", got)
+	}
+}
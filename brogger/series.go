@@ -0,0 +1,132 @@
+package brogger
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aybabtme/log"
+)
+
+// seriesPrefix is where series are listed, as `/series/<name>`.
+const seriesPrefix = "/series/"
+
+// series is a group of posts meant to be read in order.
+type series struct {
+	Name  string
+	Posts []*post // Reading order, see `seriesOrder`
+}
+
+// URL of the page listing the parts of the series.
+func (s *series) URL() string {
+	return seriesURL(s.Name)
+}
+
+// Count of parts in the series.
+func (s *series) Count() int {
+	return len(s.Posts)
+}
+
+// indexOf is the position of `p` in the series, or -1 if it isn't part of it.
+func (s *series) indexOf(p *post) int {
+	for i, part := range s.Posts {
+		if part.GetID() == p.GetID() {
+			return i
+		}
+	}
+	return -1
+}
+
+func seriesURL(name string) string {
+	return seriesPrefix + url.QueryEscape(name)
+}
+
+// newSeriesIndex groups the `posts` that are part of a series, by series
+// name.
+func newSeriesIndex(posts []*post) map[string]*series {
+	index := make(map[string]*series)
+	for _, p := range posts {
+		name := strings.TrimSpace(p.Series)
+		if name == "" {
+			continue
+		}
+		s, ok := index[name]
+		if !ok {
+			s = &series{Name: name}
+			index[name] = s
+		}
+		s.Posts = append(s.Posts, p)
+	}
+	for _, s := range index {
+		sort.Sort(seriesOrder(s.Posts))
+	}
+	return index
+}
+
+// seriesOrder sorts the parts of a series by their `SeriesOrder`, then from
+// the oldest to the most recent.
+type seriesOrder []*post
+
+func (s seriesOrder) Len() int      { return len(s) }
+func (s seriesOrder) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s seriesOrder) Less(i, j int) bool {
+	if s[i].SeriesOrder != s[j].SeriesOrder {
+		return s[i].SeriesOrder < s[j].SeriesOrder
+	}
+	return s[i].Date.Before(s[j].Date)
+}
+
+type seriesList []*series
+
+func (s seriesList) Len() int           { return len(s) }
+func (s seriesList) Less(i, j int) bool { return s[i].Name < s[j].Name }
+func (s seriesList) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+////////////////////////////////////////////////////////////////////////////////
+// HandlerFuncs
+////////////////////////////////////////////////////////////////////////////////
+
+// seriesContent is the data rendered by the series template. Without a
+// `name`, it lists all the series.
+func (b *Brog) seriesContent(lang, name string) (appContent, bool) {
+	data := b.baseContent(lang)
+	if name == "" {
+		data.AllSeries = b.postMngr.GetAllSeries(lang)
+		return data, true
+	}
+
+	s, ok := b.postMngr.GetSeries(name, lang)
+	if !ok {
+		return appContent{}, false
+	}
+	data.Series = s
+	return data, true
+}
+
+func (b *Brog) seriesFunc(rw http.ResponseWriter, req *http.Request) {
+
+	lang, _ := b.extractLanguage(req)
+
+	var name string
+	reqPath := strings.SplitN(req.RequestURI, "?", 2)[0]
+	if strings.TrimSuffix(reqPath, "/")+"/" != seriesPrefix {
+		var err error
+		name, err = url.QueryUnescape(path.Base(reqPath))
+		if err != nil {
+			b.notFound(rw, req)
+			return
+		}
+	}
+
+	data, ok := b.seriesContent(lang, name)
+	if !ok {
+		b.notFound(rw, req)
+		return
+	}
+
+	if err := b.render(rw, req, b.tmplMngr.DoWithSeries, data); err != nil {
+		log.Err(err).KV("series", name).Error("couldn't render series template")
+	}
+}
@@ -0,0 +1,73 @@
+package brogger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeriesIndex(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2014, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+	posts := []*post{
+		{id: "intro", Series: "Go", Date: date(4)},
+		{id: "part2", Series: "Go", SeriesOrder: 2, Date: date(3)},
+		{id: "part1", Series: " Go ", SeriesOrder: 1, Date: date(5)},
+		{id: "other", Date: date(2)},
+		{id: "aside", Series: "Brog", Date: date(1)},
+	}
+	index := newSeriesIndex(posts)
+
+	if len(index) != 2 {
+		t.Fatalf("Expected 2 series, got %d", len(index))
+	}
+	want := []string{"intro", "part1", "part2"}
+	got := index["Go"].Posts
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d parts, got %d", len(want), len(got))
+	}
+	for i, id := range want {
+		if got[i].id != id {
+			t.Errorf("Expected part %d to be %s, got %s", i, id, got[i].id)
+		}
+	}
+	if i := index["Go"].indexOf(posts[1]); i != 2 {
+		t.Error("Part isn't found at its position. Got", i)
+	}
+}
+
+func TestPostContentInSeries(t *testing.T) {
+	b := SetUpDefaultBrog()
+	b.postMngr = SetUpPostManager()
+	b.pageMngr = SetUpPostManager()
+
+	var parts []*post
+	for i, id := range []string{"one", "two", "three"} {
+		p := &post{id: id, Series: "Tutorial", SeriesOrder: i + 1, Language: "en"}
+		parts = append(parts, p)
+		b.postMngr.SetPost(p)
+	}
+
+	data := b.postContent("", b.postMngr, parts[1])
+	if data.Series == nil || data.Series.Count() != 3 {
+		t.Fatal("Series of the post isn't in its content")
+	}
+	if data.PrevInSeries != parts[0] || data.NextInSeries != parts[2] {
+		t.Error("Previous and next parts aren't around the post")
+	}
+
+	data = b.postContent("", b.postMngr, parts[0])
+	if data.PrevInSeries != nil || data.NextInSeries != parts[1] {
+		t.Error("First part has a previous part, or no next part")
+	}
+
+	page := &post{id: "two", Series: "Tutorial", SeriesOrder: 2, Language: "en"}
+	b.pageMngr.SetPost(page)
+	if data := b.postContent("", b.pageMngr, page); data.Series != nil || data.Related != nil {
+		t.Error("Page is shown as part of a series of posts, or related to them")
+	}
+
+	if all := b.postMngr.GetAllSeries("fr"); len(all) != 0 {
+		t.Error("Series are listed in a language they have no posts in")
+	}
+}
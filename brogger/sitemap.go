@@ -0,0 +1,163 @@
+package brogger
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/aybabtme/log"
+)
+
+const (
+	sitemapPath = "/sitemap.xml"
+	robotsPath  = "/robots.txt"
+)
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	XhtmlNS string       `xml:"xmlns:xhtml,attr,omitempty"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string             `xml:"loc"`
+	LastMod    string             `xml:"lastmod,omitempty"`
+	Alternates []sitemapAlternate `xml:"xhtml:link"`
+}
+
+// sitemapAlternate points to the version of a URL in another language.
+type sitemapAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	Hreflang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+// sitemapDate formats `date` the W3C way, or not at all if it's unknown.
+func sitemapDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.UTC().Format(time.RFC3339)
+}
+
+// renderSitemap lists the index and every published post and page, as the
+// post managers know them at that moment.
+func (b *Brog) renderSitemap(w io.Writer) error {
+	urlset := sitemapURLSet{}
+	if b.Config.Multilingual {
+		urlset.XhtmlNS = "http://www.w3.org/1999/xhtml"
+	}
+
+	posts := b.postMngr.GetAllPosts()
+	var updated time.Time
+	if len(posts) != 0 {
+		updated = posts[0].lastModified()
+	}
+	index := sitemapURL{Loc: b.absURL("/"), LastMod: sitemapDate(updated)}
+	if b.Config.Multilingual {
+		// The index is translated by its query, see `extractLanguage`
+		for _, lang := range b.Config.Languages {
+			index.Alternates = append(index.Alternates, sitemapAlternate{
+				Rel:      "alternate",
+				Hreflang: lang,
+				Href:     b.absURL("/?" + lang),
+			})
+		}
+		index.Alternates = append(index.Alternates, sitemapAlternate{
+			Rel:      "alternate",
+			Hreflang: "x-default",
+			Href:     b.absURL("/"),
+		})
+	}
+	urlset.URLs = append(urlset.URLs, index)
+
+	for _, posts := range [][]*post{posts, b.pageMngr.GetAllPosts()} {
+		translations := b.translationAlternates(posts)
+		for _, post := range posts {
+			urlset.URLs = append(urlset.URLs, sitemapURL{
+				Loc:        b.absURL(post.Permalink()),
+				LastMod:    sitemapDate(post.lastModified()),
+				Alternates: translations[post.Translation],
+			})
+		}
+	}
+
+	return writeXML(w, urlset)
+}
+
+// translationAlternates links the translations among `posts` to each other:
+// the posts in a language that share a translation key are versions of the
+// same one. There are none unless the brog is multilingual.
+func (b *Brog) translationAlternates(posts []*post) map[string][]sitemapAlternate {
+	alternates := make(map[string][]sitemapAlternate)
+	if !b.Config.Multilingual {
+		return alternates
+	}
+	translations := make(map[string][]*post)
+	for _, post := range posts {
+		if post.Translation != "" && post.Language != "" {
+			translations[post.Translation] = append(translations[post.Translation], post)
+		}
+	}
+	for key, versions := range translations {
+		if len(versions) < 2 {
+			// Not translated, nothing else to point to
+			continue
+		}
+		for _, post := range versions {
+			alternates[key] = append(alternates[key], sitemapAlternate{
+				Rel:      "alternate",
+				Hreflang: post.Language,
+				Href:     b.absURL(post.Permalink()),
+			})
+		}
+	}
+	return alternates
+}
+
+// renderRobots writes the robots.txt found at the root of the brog, or one
+// pointing crawlers to the sitemap if there is none.
+func (b *Brog) renderRobots(w io.Writer) error {
+	if fileExists(RobotsFilename) {
+		data, err := ioutil.ReadFile(RobotsFilename)
+		if err != nil {
+			return fmt.Errorf("reading '%s', %v", RobotsFilename, err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "User-agent: *\nDisallow: %s\n\nSitemap: %s\n",
+		previewPrefix, b.absURL(sitemapPath))
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// HandlerFuncs
+////////////////////////////////////////////////////////////////////////////////
+
+func (b *Brog) sitemapFunc(rw http.ResponseWriter, req *http.Request) {
+	buf := bytes.NewBuffer(nil)
+	if err := b.renderSitemap(buf); err != nil {
+		log.Err(err).Error("couldn't render sitemap")
+		b.serverError(rw, req, err)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	_, _ = buf.WriteTo(rw)
+}
+
+func (b *Brog) robotsFunc(rw http.ResponseWriter, req *http.Request) {
+	buf := bytes.NewBuffer(nil)
+	if err := b.renderRobots(buf); err != nil {
+		log.Err(err).Error("couldn't render robots.txt")
+		b.serverError(rw, req, err)
+		return
+	}
+	rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = buf.WriteTo(rw)
+}
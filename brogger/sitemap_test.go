@@ -0,0 +1,126 @@
+package brogger
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderSitemap(t *testing.T) {
+	b := SetUpFeedBrog()
+	b.pageMngr = SetUpPostManager()
+	b.pageMngr.permalink = pagePermalink
+	b.pageMngr.SetPost(&post{id: "about", Language: "en"})
+
+	modified := time.Date(2014, 2, 1, 0, 0, 0, 0, time.UTC)
+	b.postMngr.sortedPosts[0].modTime = modified
+
+	buf := bytes.NewBuffer(nil)
+	if err := b.renderSitemap(buf); err != nil {
+		t.Fatalf("Can't render sitemap: %v", err)
+	}
+	var urlset sitemapURLSet
+	if err := xml.Unmarshal(buf.Bytes(), &urlset); err != nil {
+		t.Fatalf("Sitemap isn't valid XML: %v", err)
+	}
+
+	want := []string{
+		"http://example.com/",
+		"http://example.com/posts/second",
+		"http://example.com/posts/first",
+		"http://example.com/pages/about",
+	}
+	if len(urlset.URLs) != len(want) {
+		t.Fatalf("Expected %d URLs, got %d", len(want), len(urlset.URLs))
+	}
+	for i, loc := range want {
+		if urlset.URLs[i].Loc != loc {
+			t.Errorf("Expected URL %d to be %s, got %s", i, loc, urlset.URLs[i].Loc)
+		}
+	}
+	if urlset.URLs[1].LastMod != "2014-02-01T00:00:00Z" {
+		t.Error("Last modification isn't the mtime of the file. Got", urlset.URLs[1].LastMod)
+	}
+	if urlset.URLs[2].LastMod != "2014-01-01T00:00:00Z" {
+		t.Error("Last modification isn't the date of the post. Got", urlset.URLs[2].LastMod)
+	}
+	if strings.Contains(buf.String(), "hreflang") {
+		t.Error("Unilingual sitemap has language alternates")
+	}
+}
+
+func TestRenderSitemapMultilingual(t *testing.T) {
+	b := SetUpFeedBrog()
+	b.Config.Multilingual = true
+	b.Config.Languages = []string{"en", "fr"}
+	b.pageMngr = SetUpPostManager()
+
+	buf := bytes.NewBuffer(nil)
+	if err := b.renderSitemap(buf); err != nil {
+		t.Fatalf("Can't render sitemap: %v", err)
+	}
+	for _, link := range []string{
+		`hreflang="fr" href="http://example.com/?fr"`,
+		`hreflang="x-default" href="http://example.com/"`,
+	} {
+		if !strings.Contains(buf.String(), link) {
+			t.Errorf("Sitemap is missing alternate link %s", link)
+		}
+	}
+	if strings.Contains(buf.String(), `href="http://example.com/posts/second"`) {
+		t.Error("Post without translations has language alternates")
+	}
+
+	for _, post := range b.postMngr.sortedPosts {
+		post.Translation = "greeting"
+	}
+	buf.Reset()
+	if err := b.renderSitemap(buf); err != nil {
+		t.Fatalf("Can't render sitemap: %v", err)
+	}
+	// Each translation lists them all, itself included
+	for _, link := range []string{
+		`hreflang="fr" href="http://example.com/posts/second"`,
+		`hreflang="en" href="http://example.com/posts/first"`,
+	} {
+		if n := strings.Count(buf.String(), link); n != 2 {
+			t.Errorf("Expected alternate link %s in both translations, found it %d times", link, n)
+		}
+	}
+}
+
+func TestRenderRobots(t *testing.T) {
+	b := SetUpFeedBrog()
+	buf := bytes.NewBuffer(nil)
+	if err := b.renderRobots(buf); err != nil {
+		t.Fatalf("Can't render robots.txt: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Sitemap: http://example.com/sitemap.xml") {
+		t.Error("robots.txt doesn't point to the sitemap. Got", buf.String())
+	}
+}
+
+func TestRobotsFailureNotSent(t *testing.T) {
+	b := SetUpFeedBrog()
+	b.isProd = true
+	// Unreadable robots.txt
+	if err := os.Mkdir(RobotsFilename, 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(RobotsFilename)
+
+	rec := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", robotsPath, nil)
+	b.robotsFunc(rec, req)
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), RobotsFilename) {
+		t.Errorf("Error detail sent in production, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,137 @@
+package brogger
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aybabtme/log"
+)
+
+// Kinds of taxonomies posts can be classified in, also used as the first
+// segment of their URLs.
+const (
+	tagsTaxonomy       = "tags"
+	categoriesTaxonomy = "categories"
+)
+
+var allTaxonomies = []string{tagsTaxonomy, categoriesTaxonomy}
+
+// termsOf returns the terms under which `p` is classified in `kind`.
+func termsOf(kind string, p *post) []string {
+	switch kind {
+	case tagsTaxonomy:
+		return p.Tags
+	case categoriesTaxonomy:
+		return p.Categories
+	}
+	return nil
+}
+
+// tag is a term of a taxonomy, along with how many posts it classifies.
+type tag struct {
+	Name  string
+	Count int
+}
+
+// taxonomy indexes posts by the terms they are classified under. Posts
+// of a term are kept in the order they were indexed.
+type taxonomy map[string][]*post
+
+func newTaxonomy(kind string, posts []*post) taxonomy {
+	tx := make(taxonomy)
+	for _, p := range posts {
+		for _, term := range termsOf(kind, p) {
+			term = strings.TrimSpace(term)
+			if term == "" {
+				continue
+			}
+			tx[term] = append(tx[term], p)
+		}
+	}
+	return tx
+}
+
+// tags lists the terms that classify at least one post in `lang`, sorted by
+// name.
+func (tx taxonomy) tags(lang string) []tag {
+	var tags []tag
+	for name := range tx {
+		if count := len(tx.posts(name, lang)); count != 0 {
+			tags = append(tags, tag{Name: name, Count: count})
+		}
+	}
+	sort.Sort(tagList(tags))
+	return tags
+}
+
+// posts lists the posts of `term` in `lang`.
+func (tx taxonomy) posts(term, lang string) []*post {
+	var posts []*post
+	for _, p := range tx[term] {
+		if lang == "" || p.Language == lang {
+			posts = append(posts, p)
+		}
+	}
+	return posts
+}
+
+type tagList []tag
+
+func (t tagList) Len() int           { return len(t) }
+func (t tagList) Less(i, j int) bool { return t[i].Name < t[j].Name }
+func (t tagList) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+
+////////////////////////////////////////////////////////////////////////////////
+// HandlerFuncs
+////////////////////////////////////////////////////////////////////////////////
+
+// tagsContent is the data rendered by the tag template for `kind`. Without
+// a `term`, it lists all the terms of `kind`.
+func (b *Brog) tagsContent(lang, kind, term string) appContent {
+	data := b.baseContent(lang)
+	data.Taxonomy = kind
+	data.Tag = term
+	if term == "" {
+		data.Tags = b.postMngr.GetTags(kind, lang)
+	} else {
+		data.Posts = b.postMngr.GetPostsWithTag(kind, term, lang)
+	}
+	return data
+}
+
+// handleTaxonomies registers the listing pages of every taxonomy.
+func (b *Brog) handleTaxonomies() {
+	for _, kind := range allTaxonomies {
+		b.HandleFunc("/"+kind+"/", b.prometheusHandler(b.taxonomyFunc(kind), "srv", kind))
+	}
+}
+
+func (b *Brog) taxonomyFunc(kind string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		lang, _ := b.extractLanguage(req)
+
+		var term string
+		reqPath := strings.SplitN(req.RequestURI, "?", 2)[0]
+		if strings.TrimSuffix(reqPath, "/") != "/"+kind {
+			var err error
+			term, err = url.QueryUnescape(path.Base(reqPath))
+			if err != nil {
+				b.notFound(rw, req)
+				return
+			}
+		}
+
+		data := b.tagsContent(lang, kind, term)
+		if term != "" && len(data.Posts) == 0 {
+			b.notFound(rw, req)
+			return
+		}
+
+		if err := b.render(rw, req, b.tmplMngr.DoWithTag, data); err != nil {
+			log.Err(err).KV("taxonomy", kind).KV("tag", term).Error("couldn't render tag template")
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package brogger
+
+import (
+	"testing"
+)
+
+func TestTaxonomy(t *testing.T) {
+	posts := []*post{
+		{id: "a", Language: "en", Tags: []string{"go", "brog"}},
+		{id: "b", Language: "fr", Tags: []string{"go"}},
+		{id: "c", Language: "en", Tags: []string{" ", "go"}, Categories: []string{"meta"}},
+	}
+	tx := newTaxonomy(tagsTaxonomy, posts)
+
+	tags := tx.tags("")
+	if len(tags) != 2 || tags[0].Name != "brog" || tags[1].Name != "go" {
+		t.Fatalf("Tags aren't listed by name, or blank tags were kept. Got %v", tags)
+	}
+	if tags[1].Count != 3 {
+		t.Error("Tag 'go' should classify 3 posts. Got", tags[1].Count)
+	}
+
+	if posts := tx.posts("go", "fr"); len(posts) != 1 || posts[0].id != "b" {
+		t.Error("Posts of a tag aren't filtered by language")
+	}
+	if tags := tx.tags("fr"); len(tags) != 1 {
+		t.Error("Tags without posts in a language are listed for that language")
+	}
+
+	if categories := newTaxonomy(categoriesTaxonomy, posts).tags(""); len(categories) != 1 {
+		t.Error("Categories aren't indexed separately from tags")
+	}
+}
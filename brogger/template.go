@@ -2,12 +2,13 @@ package brogger
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 
 	"github.com/aybabtme/log"
-	"github.com/howeyc/fsnotify"
 
 	// Using `text` instead of `html` to keep the
 	// JS/CSS/HTML from inside Markdown posts
@@ -19,6 +20,12 @@ const (
 	indexTmplName      = "index.gohtml"
 	postTmplName       = "post.gohtml"
 	langSelectTmplName = "langselect.gohtml"
+	tagTmplName        = "tag.gohtml"
+	searchTmplName     = "search.gohtml"
+	archiveTmplName    = "archive.gohtml"
+	seriesTmplName     = "series.gohtml"
+	notFoundTmplName   = "404.gohtml"
+	errorTmplName      = "500.gohtml"
 	styleTmplName      = "style.gohtml"
 	jsTmplName         = "javascript.gohtml"
 	headerTmplName     = "header.gohtml"
@@ -29,32 +36,57 @@ type templateManager struct {
 	brog *Brog
 	path string
 
-	watcher *fsnotify.Watcher // Listens on `path`
-	die     chan struct{}     // To kill the watcher goroutine
+	watcher watcher       // Listens on `path`
+	die     chan struct{} // To kill the watcher goroutine
 
 	mu         sync.RWMutex // Locks the templates
 	index      *template.Template
 	post       *template.Template
 	langselect *template.Template
+	tag        *template.Template
+	search     *template.Template
+	archive    *template.Template
+	series     *template.Template
+	notFound   *template.Template
+	error      *template.Template
 }
 
+// newTemplateManager parses the templates found at `templPath`, without
+// watching them for changes.
+func newTemplateManager(brog *Brog, templPath string) (*templateManager, error) {
+	tmpMngr := &templateManager{
+		brog: brog,
+		path: templPath,
+		die:  make(chan struct{}),
+		mu:   sync.RWMutex{},
+	}
+
+	if err := tmpMngr.initializeAppTmpl(); err != nil {
+		return nil, fmt.Errorf("initializing templates, %v", err)
+	}
+
+	return tmpMngr, nil
+}
+
+// startTemplateManager parses the templates found at `templPath` and
+// watches them for changes. Missing templates are written back first, if
+// the config says so; builds leave the source tree as it is.
 func startTemplateManager(brog *Brog, templPath string) (*templateManager, error) {
 
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return nil, fmt.Errorf("getting template watcher, %v", err)
+	if brog.Config.RewriteMissing {
+		if err := replicateMissingTemplates(templPath); err != nil {
+			return nil, err
+		}
 	}
 
-	tmpMngr := &templateManager{
-		brog:    brog,
-		path:    templPath,
-		watcher: watcher,
-		die:     make(chan struct{}),
-		mu:      sync.RWMutex{},
+	tmpMngr, err := newTemplateManager(brog, templPath)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := tmpMngr.initializeAppTmpl(); err != nil {
-		return nil, fmt.Errorf("initializing templates, %v", err)
+	tmpMngr.watcher, err = brog.newWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("getting template watcher, %v", err)
 	}
 
 	if err := tmpMngr.watchForChanges(templPath); err != nil {
@@ -82,79 +114,183 @@ func (t *templateManager) DoWithLangSelect(do func(*template.Template)) {
 	do(t.langselect)
 }
 
+func (t *templateManager) DoWithTag(do func(*template.Template)) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	do(t.tag)
+}
+
+func (t *templateManager) DoWithSearch(do func(*template.Template)) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	do(t.search)
+}
+
+func (t *templateManager) DoWithArchive(do func(*template.Template)) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	do(t.archive)
+}
+
+func (t *templateManager) DoWithSeries(do func(*template.Template)) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	do(t.series)
+}
+
+func (t *templateManager) DoWithNotFound(do func(*template.Template)) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	do(t.notFound)
+}
+
+func (t *templateManager) DoWithError(do func(*template.Template)) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	do(t.error)
+}
+
 func (t *templateManager) Close() error {
+	if t.watcher == nil {
+		// Never started watching
+		return nil
+	}
 	t.die <- struct{}{}
 	return t.watcher.Close()
 }
 
 func (t *templateManager) initializeAppTmpl() error {
 
-	prefix := func(filename string) string {
-		return filepath.Join(t.brog.Config.TemplatePath, filename)
+	index, err := t.parseWithApp(indexTmplName)
+	if err != nil {
+		return err
 	}
-
-	indexApp, err := template.ParseFiles(
-		prefix(appTmplName),
-		prefix(styleTmplName),
-		prefix(jsTmplName),
-		prefix(headerTmplName),
-		prefix(footerTmplName),
-	)
+	post, err := t.parseWithApp(postTmplName)
+	if err != nil {
+		return err
+	}
+	langSelect, err := t.parseWithApp(langSelectTmplName)
 	if err != nil {
-		return fmt.Errorf("parsing indexApp template, %v", err)
+		return err
 	}
-	index, err := indexApp.ParseFiles(prefix(indexTmplName))
+	tag, err := t.parseWithApp(tagTmplName)
 	if err != nil {
-		return fmt.Errorf("parsing index template at '%s', %v", prefix(indexTmplName), err)
-	}
-	postApp, err := template.ParseFiles(
-		prefix(appTmplName),
-		prefix(styleTmplName),
-		prefix(jsTmplName),
-		prefix(headerTmplName),
-		prefix(footerTmplName),
-	)
+		return err
+	}
+	search, err := t.parseWithApp(searchTmplName)
 	if err != nil {
-		return fmt.Errorf("parsing postApp template, %v", err)
+		return err
 	}
-	post, err := postApp.ParseFiles(prefix(postTmplName))
+	archive, err := t.parseWithApp(archiveTmplName)
 	if err != nil {
-		return fmt.Errorf("parsing post template at '%s', %v", prefix(postTmplName), err)
+		return err
 	}
-
-	langSelectApp, err := template.ParseFiles(
-		prefix(appTmplName),
-		prefix(styleTmplName),
-		prefix(jsTmplName),
-		prefix(headerTmplName),
-		prefix(footerTmplName),
-	)
+	series, err := t.parseWithApp(seriesTmplName)
 	if err != nil {
-		return fmt.Errorf("parsing langSelectApp template, %v", err)
+		return err
 	}
-	langSelect, err := langSelectApp.ParseFiles(prefix(langSelectTmplName))
+	notFound, err := t.parseWithApp(notFoundTmplName)
 	if err != nil {
-		return fmt.Errorf("parsing langSelect template at '%s', %v", prefix(langSelectTmplName), err)
+		return err
+	}
+	errorTmpl, err := t.parseWithApp(errorTmplName)
+	if err != nil {
+		return err
 	}
 
 	t.mu.Lock()
 	t.index = index
 	t.post = post
 	t.langselect = langSelect
+	t.tag = tag
+	t.search = search
+	t.archive = archive
+	t.series = series
+	t.notFound = notFound
+	t.error = errorTmpl
 	t.mu.Unlock()
 
+	t.brog.contentChanged()
+	return nil
+}
+
+// parseWithApp parses the content template `name` along with the application
+// templates that wrap it.
+func (t *templateManager) parseWithApp(name string) (*template.Template, error) {
+
+	app := template.New(appTmplName).Funcs(t.funcs())
+	for _, filename := range []string{appTmplName, styleTmplName, jsTmplName, headerTmplName, footerTmplName} {
+		if err := t.parseFile(app, filename); err != nil {
+			return nil, fmt.Errorf("parsing application templates for '%s', %v", name, err)
+		}
+	}
+	if err := t.parseFile(app, name); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// parseFile parses template file `filename` of the template path into
+// `tmpl`, like ParseFiles does. Files that are missing are taken from the
+// default templates if the config says so, which is how builds go without
+// writing them back.
+func (t *templateManager) parseFile(tmpl *template.Template, filename string) error {
+	fullpath := filepath.Join(t.brog.Config.TemplatePath, filename)
+	data, err := ioutil.ReadFile(fullpath)
+	if os.IsNotExist(err) && t.brog.Config.RewriteMissing {
+		if packed, ok := allTemplates[filename]; ok {
+			data, err = packed.data, nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("parsing template at '%s', %v", fullpath, err)
+	}
+
+	if filename != tmpl.Name() {
+		tmpl = tmpl.New(filename)
+	}
+	if _, err := tmpl.Parse(string(data)); err != nil {
+		return fmt.Errorf("parsing template at '%s', %v", fullpath, err)
+	}
+	return nil
+}
+
+// funcs are the functions templates can call.
+func (t *templateManager) funcs() template.FuncMap {
+	return template.FuncMap{
+		"asset": t.brog.assetURL,
+	}
+}
+
+// replicateMissingTemplates writes back the default version of the templates that
+// don't exist in the template path, such as those added by newer versions
+// of brog.
+func replicateMissingTemplates(templPath string) error {
+	for filename, tmpl := range allTemplates {
+		if fileExists(filepath.Join(templPath, filename)) {
+			continue
+		}
+		log.KV("file.name", filename).Info("replicating missing template")
+		if err := tmpl.replicateInDir(templPath); err != nil {
+			return fmt.Errorf("replicating template '%s', %v", filename, err)
+		}
+	}
 	return nil
 }
 
 func (t *templateManager) watchForChanges(dirname string) error {
 	go func() {
+		queue := newEventQueue(t.processTemplateEvent)
 		for {
 			select {
-			case ev := <-t.watcher.Event:
-				t.processTemplateEvent(ev)
-			case err := <-t.watcher.Error:
+			case ev := <-t.watcher.Events():
+				queue.add(ev)
+			case <-queue.ready():
+				queue.flush()
+			case err := <-t.watcher.Errors():
 				log.Err(err).KV("dir.name", dirname).Error("error watching templates")
 			case <-t.die:
+				queue.stop()
 				return
 			}
 		}
@@ -163,79 +299,77 @@ func (t *templateManager) watchForChanges(dirname string) error {
 	return t.watcher.Watch(dirname)
 }
 
-func (t *templateManager) processTemplateEvent(ev *fsnotify.FileEvent) {
-	ext := strings.ToLower(filepath.Ext(ev.Name))
+func (t *templateManager) processTemplateEvent(ev fileEvent, lastTry bool) error {
+	ext := strings.ToLower(filepath.Ext(ev.name))
 	switch ext {
 	case ".gohtml":
 	case ".tmpl":
 	default:
-		log.KV("ext", ext).KV("file.name", ev.Name).Info("ignoring file")
-		return
-	}
-
-	if ev.IsCreate() {
-		// Nothing to do, all usefull templates files MUST exist at this
-		// time or brog would not have started.
-		return
+		log.KV("ext", ext).KV("file.name", ev.name).Info("ignoring file")
+		return nil
 	}
 
-	if ev.IsModify() {
-		t.processTemplateModify(ev)
-		return
+	switch ev.op {
+	case fileCreated, fileModified:
+		// Created too when an editor saves a template by replacing it
+		return t.processTemplateModify(ev.name, lastTry)
+	case fileRenamed, fileDeleted:
+		t.processTemplateDelete(ev.name)
 	}
-
-	if ev.IsRename() || ev.IsDelete() {
-		t.processTemplateDelete(ev)
-		return
-	}
-
-	log.KV("file.event", ev.String()).Error("unknown file event")
+	return nil
 }
 
-func (t *templateManager) processTemplateModify(ev *fsnotify.FileEvent) {
-	filename := filepath.Base(ev.Name)
+// processTemplateModify parses the templates again. If they are invalid, it
+// fails so that it can be called again once the template is fully written,
+// unless it's the last try.
+func (t *templateManager) processTemplateModify(name string, lastTry bool) error {
+	filename := filepath.Base(name)
 	tmpl, ok := allTemplates[filename]
 	if !ok {
-		log.KV("file.name", ev.Name).Error("ignoring, brog can only use its default templates")
-		return
+		log.KV("file.name", name).Error("ignoring, brog can only use its default templates")
+		return nil
 	}
 
-	ll := log.KV("file.name", ev.Name)
-	ll.Info("template name changed, parsing templates again")
+	ll := log.KV("file.name", name)
+	ll.Info("template changed, parsing templates again")
 	err := t.initializeAppTmpl()
 	if err == nil {
 		ll.Info("new templates have been assimilated")
-		return
+		return nil
+	}
+	if !lastTry {
+		return err
 	}
 	ll.Err(err).Error("failed to reinitialize templates, reconstructing")
 
 	if !t.brog.Config.RewriteInvalid {
 		// Nothing to do, just fail
-		return
+		return nil
 	}
 
 	if err := tmpl.rewriteInDir(t.brog.Config.TemplatePath); err != nil {
 		ll.Error("reconstruction failed")
-		return
+		return nil
 	}
 
 	if err := t.initializeAppTmpl(); err != nil {
 		ll.Err(err).Error("failed to reload templates after reconstruction")
-		return
+		return nil
 	}
 
 	ll.Info("threat to template has been eradicated. Resistance is futile.  You will be assimilated")
+	return nil
 }
 
-func (t *templateManager) processTemplateDelete(ev *fsnotify.FileEvent) {
+func (t *templateManager) processTemplateDelete(name string) {
 
-	filename := filepath.Base(ev.Name)
+	filename := filepath.Base(name)
 	tmpl, ok := allTemplates[filename]
 	if !ok {
 		// Don't care
 		return
 	}
-	ll := log.KV("file.name", ev.Name)
+	ll := log.KV("file.name", name)
 	ll.Error("detected the destruction of a vital part")
 
 	if !t.brog.Config.RewriteMissing {
@@ -0,0 +1,104 @@
+package brogger
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strconv"
+
+	"github.com/russross/blackfriday"
+	"github.com/shurcooL/sanitized_anchor_name"
+)
+
+// minTOCHeadings is how many headings a post needs to get a table of
+// contents, unless its front matter says otherwise.
+const minTOCHeadings = 3
+
+// heading is a section of a post, linked from its table of contents.
+type heading struct {
+	Level int
+	ID    string // Anchor of the heading, unique in the post
+	Title string // Text of the heading, without markup
+}
+
+// headingRenderer renders headings with an anchor and a link to it, and
+// remembers them for the table of contents.
+type headingRenderer struct {
+	blackfriday.Renderer
+	headings []heading
+	ids      map[string]bool // Anchors given so far
+}
+
+func newHeadingRenderer(renderer blackfriday.Renderer) *headingRenderer {
+	return &headingRenderer{
+		Renderer: renderer,
+		ids:      make(map[string]bool),
+	}
+}
+
+func (r *headingRenderer) Header(out *bytes.Buffer, text func() bool, level int, id string) {
+	marker := out.Len()
+	if !text() {
+		out.Truncate(marker)
+		return
+	}
+	inner := string(out.Bytes()[marker:])
+	out.Truncate(marker)
+
+	title := plainText(inner)
+	if id == "" {
+		id = sanitized_anchor_name.Create(title)
+	}
+	id = r.uniqueID(id)
+	r.headings = append(r.headings, heading{Level: level, ID: id, Title: title})
+
+	if out.Len() > 0 {
+		out.WriteByte('\n')
+	}
+	anchor := html.EscapeString(id)
+	fmt.Fprintf(out, "<h%d id=\"%s\">%s<a class=\"anchor\" href=\"#%s\" aria-label=\"Link to this section\"></a></h%d>\n",
+		level, anchor, inner, anchor, level)
+}
+
+// uniqueID suffixes `id` with a number if a previous heading has it, like
+// `intro`, `intro-1`, `intro-2`. Headings without letters nor digits are
+// `section`.
+func (r *headingRenderer) uniqueID(id string) string {
+	if id == "" {
+		id = "section"
+	}
+	unique := id
+	for i := 1; r.ids[unique]; i++ {
+		unique = id + "-" + strconv.Itoa(i)
+	}
+	r.ids[unique] = true
+	return unique
+}
+
+// renderTOC lists `headings` in nested lists following their levels.
+func renderTOC(headings []heading) string {
+	if len(headings) == 0 {
+		return ""
+	}
+
+	buf := bytes.NewBufferString("<nav class=\"toc\">\n")
+	var levels []int // Levels of the lists still open
+	for _, h := range headings {
+		for len(levels) != 0 && h.Level < levels[len(levels)-1] {
+			buf.WriteString("</li>\n</ul>\n")
+			levels = levels[:len(levels)-1]
+		}
+		if len(levels) == 0 || h.Level > levels[len(levels)-1] {
+			buf.WriteString("<ul>\n")
+			levels = append(levels, h.Level)
+		} else {
+			buf.WriteString("</li>\n")
+		}
+		fmt.Fprintf(buf, "<li><a href=\"#%s\">%s</a>", html.EscapeString(h.ID), html.EscapeString(h.Title))
+	}
+	for range levels {
+		buf.WriteString("</li>\n</ul>\n")
+	}
+	buf.WriteString("</nav>")
+	return buf.String()
+}
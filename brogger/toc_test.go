@@ -0,0 +1,63 @@
+package brogger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHeadingAnchors(t *testing.T) {
+	markdown := "# Intro\n\ntext\n\n## Déjà vu\n\n## Intro\n\n## ???\n\n## Pinned {#here}\n"
+	content, headings := markdownWithHTML([]byte(markdown), false)
+
+	want := []heading{
+		{1, "intro", "Intro"},
+		{2, "déjà-vu", "Déjà vu"},
+		{2, "intro-1", "Intro"},
+		{2, "section", "???"},
+		{2, "here", "Pinned"},
+	}
+	if len(headings) != len(want) {
+		t.Fatalf("Expected %d headings, got %v", len(want), headings)
+	}
+	for i := range want {
+		if headings[i] != want[i] {
+			t.Errorf("Expected heading %v, got %v", want[i], headings[i])
+		}
+	}
+
+	html := string(content)
+	if !strings.Contains(html, `<h2 id="intro-1">Intro<a class="anchor" href="#intro-1"`) {
+		t.Error("Heading has no anchor or link to it. Got", html)
+	}
+	if text := plainText(html); !strings.HasPrefix(text, "Intro text Déjà vu") {
+		t.Error("Links to headings show in the text of the post. Got", text)
+	}
+}
+
+func TestRenderTOC(t *testing.T) {
+	toc := renderTOC([]heading{
+		{2, "a", "A"},
+		{3, "b", "B & C"},
+		{2, "d", "D"},
+	})
+	want := "<nav class=\"toc\">\n<ul>\n" +
+		"<li><a href=\"#a\">A</a><ul>\n<li><a href=\"#b\">B &amp; C</a></li>\n</ul>\n" +
+		"</li>\n<li><a href=\"#d\">D</a></li>\n</ul>\n</nav>"
+	if toc != want {
+		t.Errorf("Expected table of contents\n%s\ngot\n%s", want, toc)
+	}
+}
+
+func TestPostTOCSwitch(t *testing.T) {
+	show, hide := true, false
+	short := &post{headings: []heading{{2, "a", "A"}}}
+	long := &post{headings: []heading{{2, "a", "A"}, {2, "b", "B"}, {2, "c", "C"}}}
+
+	if short.TOC() != "" || long.TOC() == "" {
+		t.Error("Table of contents doesn't depend on the number of headings by default")
+	}
+	short.ShowTOC, long.ShowTOC = &show, &hide
+	if short.TOC() == "" || long.TOC() != "" {
+		t.Error("Front matter doesn't switch the table of contents")
+	}
+}
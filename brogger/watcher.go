@@ -0,0 +1,265 @@
+package brogger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/howeyc/fsnotify"
+)
+
+// How posts and templates are watched for changes.
+const (
+	// Told of changes by the OS, with inotify and the like.
+	notifyWatcher = "notify"
+	// By listing files again and again, for file systems that don't tell
+	// of changes, like NFS, some Docker volumes or content synced in by
+	// another container.
+	pollWatcher = "poll"
+)
+
+func validWatcher(kind string) bool {
+	return kind == notifyWatcher || kind == pollWatcher
+}
+
+// watcher reports the changes to the files of the directories it watches,
+// not those of their subdirectories.
+type watcher interface {
+	Watch(dirname string) error
+	RemoveWatch(dirname string) error
+	Events() <-chan fileEvent
+	Errors() <-chan error
+	Close() error
+}
+
+// newWatcher creates the kind of watcher set in the config.
+func (b *Brog) newWatcher() (watcher, error) {
+	if b.Config.Watcher != pollWatcher {
+		return newFsnotifyWatcher()
+	}
+	interval, err := time.ParseDuration(b.Config.PollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("parsing poll interval, %v", err)
+	}
+	return newPollingWatcher(interval), nil
+}
+
+// fsnotifyWatcher is told of changes by the OS.
+type fsnotifyWatcher struct {
+	*fsnotify.Watcher
+	events chan fileEvent
+	done   chan struct{}
+}
+
+func newFsnotifyWatcher() (*fsnotifyWatcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &fsnotifyWatcher{
+		Watcher: fsw,
+		events:  make(chan fileEvent),
+		done:    make(chan struct{}),
+	}
+	go w.forward()
+	return w, nil
+}
+
+func (w *fsnotifyWatcher) forward() {
+	for ev := range w.Watcher.Event {
+		fileEv, ok := newFileEvent(ev)
+		if !ok {
+			continue
+		}
+		select {
+		case w.events <- fileEv:
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func newFileEvent(ev *fsnotify.FileEvent) (fileEvent, bool) {
+	switch {
+	case ev.IsCreate():
+		return fileEvent{name: ev.Name, op: fileCreated}, true
+	case ev.IsModify():
+		return fileEvent{name: ev.Name, op: fileModified}, true
+	case ev.IsRename():
+		return fileEvent{name: ev.Name, op: fileRenamed}, true
+	case ev.IsDelete():
+		return fileEvent{name: ev.Name, op: fileDeleted}, true
+	}
+	return fileEvent{}, false
+}
+
+func (w *fsnotifyWatcher) Events() <-chan fileEvent { return w.events }
+func (w *fsnotifyWatcher) Errors() <-chan error     { return w.Watcher.Error }
+
+func (w *fsnotifyWatcher) Close() error {
+	close(w.done)
+	return w.Watcher.Close()
+}
+
+// pollingWatcher lists the files of the directories it watches every
+// `interval`, and reports those whose size or modification time changed.
+type pollingWatcher struct {
+	interval time.Duration
+	events   chan fileEvent
+	errors   chan error
+	done     chan struct{}
+
+	mu   sync.Mutex                      // Locks `dirs`
+	dirs map[string]map[string]fileState // Files last seen in each directory, by name
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+	isDir   bool
+}
+
+func newPollingWatcher(interval time.Duration) *pollingWatcher {
+	w := &pollingWatcher{
+		interval: interval,
+		events:   make(chan fileEvent),
+		errors:   make(chan error),
+		done:     make(chan struct{}),
+		dirs:     make(map[string]map[string]fileState),
+	}
+	go w.poll()
+	return w
+}
+
+func (w *pollingWatcher) Watch(dirname string) error {
+	files, err := listFileStates(dirname)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.dirs[dirname] = files
+	w.mu.Unlock()
+	return nil
+}
+
+func (w *pollingWatcher) RemoveWatch(dirname string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.dirs[dirname]; !ok {
+		return fmt.Errorf("can't remove non-existent watch for '%s'", dirname)
+	}
+	delete(w.dirs, dirname)
+	return nil
+}
+
+func (w *pollingWatcher) Events() <-chan fileEvent { return w.events }
+func (w *pollingWatcher) Errors() <-chan error     { return w.errors }
+
+func (w *pollingWatcher) Close() error {
+	close(w.done)
+	return nil
+}
+
+func (w *pollingWatcher) poll() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !w.scan() {
+				return
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// scan lists the watched directories again and reports what changed since
+// the last time. It returns false once the watcher is closed.
+func (w *pollingWatcher) scan() bool {
+	w.mu.Lock()
+	dirnames := make([]string, 0, len(w.dirs))
+	for dirname := range w.dirs {
+		dirnames = append(dirnames, dirname)
+	}
+	w.mu.Unlock()
+
+	for _, dirname := range dirnames {
+		files, err := listFileStates(dirname)
+		if os.IsNotExist(err) {
+			// Reported as removed by its parent
+			continue
+		}
+		if err != nil {
+			select {
+			case w.errors <- err:
+				continue
+			case <-w.done:
+				return false
+			}
+		}
+
+		w.mu.Lock()
+		last, ok := w.dirs[dirname]
+		if ok {
+			w.dirs[dirname] = files
+		}
+		w.mu.Unlock()
+		if !ok {
+			// Stopped watching it meanwhile
+			continue
+		}
+
+		for _, ev := range diffFileStates(dirname, last, files) {
+			select {
+			case w.events <- ev:
+			case <-w.done:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func listFileStates(dirname string) (map[string]fileState, error) {
+	fileInfos, err := ioutil.ReadDir(dirname)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]fileState, len(fileInfos))
+	for _, info := range fileInfos {
+		files[info.Name()] = fileState{
+			size:    info.Size(),
+			modTime: info.ModTime(),
+			isDir:   info.IsDir(),
+		}
+	}
+	return files, nil
+}
+
+// diffFileStates tells what happened to the files of `dirname` to go from
+// `last` to `cur`. Files gone are reported as renamed: polling can't tell a
+// deleted file from a renamed one, and a renamed post keeps redirecting
+// from its old URL if its new name shows up soon enough.
+func diffFileStates(dirname string, last, cur map[string]fileState) []fileEvent {
+	var events []fileEvent
+	for name, state := range cur {
+		lastState, ok := last[name]
+		switch {
+		case !ok:
+			events = append(events, fileEvent{name: filepath.Join(dirname, name), op: fileCreated})
+		case !state.isDir && (state.size != lastState.size || !state.modTime.Equal(lastState.modTime)):
+			events = append(events, fileEvent{name: filepath.Join(dirname, name), op: fileModified})
+		}
+	}
+	for name := range last {
+		if _, ok := cur[name]; !ok {
+			events = append(events, fileEvent{name: filepath.Join(dirname, name), op: fileRenamed})
+		}
+	}
+	return events
+}
@@ -0,0 +1,98 @@
+package brogger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPollingWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brog-poll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "hello.md")
+
+	w := newPollingWatcher(10 * time.Millisecond)
+	defer w.Close()
+	if err := w.Watch(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := func(want fileEvent) {
+		select {
+		case ev := <-w.Events():
+			if ev != want {
+				t.Errorf("Expected %v, got %v", want, ev)
+			}
+		case err := <-w.Errors():
+			t.Fatal(err)
+		case <-time.After(time.Second):
+			t.Fatalf("No event while expecting %v", want)
+		}
+	}
+
+	if err := ioutil.WriteFile(filename, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expect(fileEvent{name: filename, op: fileCreated})
+
+	if err := ioutil.WriteFile(filename, []byte("hello world"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expect(fileEvent{name: filename, op: fileModified})
+
+	if err := os.Remove(filename); err != nil {
+		t.Fatal(err)
+	}
+	expect(fileEvent{name: filename, op: fileRenamed})
+
+	if err := w.RemoveWatch(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filename, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case ev := <-w.Events():
+		t.Error("Event after the watch was removed:", ev)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDiffFileStates(t *testing.T) {
+	now := time.Now()
+	last := map[string]fileState{
+		"same.md":    {size: 1, modTime: now},
+		"written.md": {size: 1, modTime: now},
+		"touched.md": {size: 1, modTime: now},
+		"gone.md":    {size: 1, modTime: now},
+		"dir":        {size: 4096, modTime: now, isDir: true},
+	}
+	cur := map[string]fileState{
+		"same.md":    {size: 1, modTime: now},
+		"written.md": {size: 2, modTime: now},
+		"touched.md": {size: 1, modTime: now.Add(time.Second)},
+		"new.md":     {size: 1, modTime: now},
+		"dir":        {size: 4096, modTime: now.Add(time.Second), isDir: true},
+	}
+
+	want := map[string]fileOp{
+		"written.md": fileModified,
+		"touched.md": fileModified,
+		"new.md":     fileCreated,
+		"gone.md":    fileRenamed,
+	}
+	events := diffFileStates("posts", last, cur)
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %v", len(want), events)
+	}
+	for _, ev := range events {
+		if op, ok := want[filepath.Base(ev.name)]; !ok || op != ev.op || filepath.Dir(ev.name) != "posts" {
+			t.Errorf("Unexpected event %v", ev)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aybabtme/brog/brogger"
 	"github.com/aybabtme/log"
@@ -20,12 +21,16 @@ const (
 	Page = "page"
 	// Server starts brog at the current path
 	Server = "server"
+	// Build renders brog at the current path to static files
+	Build = "build"
+	// Preview creates a URL to share a draft
+	Preview = "preview"
 	// Help shows the usage string
 	Help = "help"
 	// Version shows the current version of brog
 	Version = "version"
 
-	usage = `usage: brog {init | server [prod] | create [new post name] | page [new page name] | version}
+	usage = `usage: brog {init | server [prod] | build [outdir] | preview [post id] [duration] | create [new post name] | page [new page name] | version}
 
 'brog' is a tool to initialize brog structures, serve the content
 of brog structures and create new posts in a brog structure.
@@ -42,6 +47,18 @@ The following are brog's valid commands with the arguments they take :
                           port number specified in the config file. By
                           default, brog runs in development mode.
 
+    brog build [outdir]   Renders the brog structure at the current
+                          location to static files in [outdir], ready
+                          to be served by any web server.  By default,
+                          uses the build path specified in the config
+                          file.
+
+    brog preview [id] [duration]
+                          Prints a signed URL where the post with ID
+                          [id] can be seen, even if it isn't published,
+                          until [duration] (such as 48h) has elapsed.
+                          By default, the URL is valid for a week.
+
     brog create [name]    Creates a blank post in file [name], in the
                           location specified by the config file.
 
@@ -68,6 +85,22 @@ func main() {
 				doServer(false)
 			}
 			return
+		case Build:
+			if len(commands) > i+1 {
+				doBuild(commands[i+1])
+			} else {
+				doBuild("")
+			}
+			return
+		case Preview:
+			if len(commands) > i+2 {
+				doPreview(commands[i+1], commands[i+2])
+			} else if len(commands) > i+1 {
+				doPreview(commands[i+1], "")
+			} else {
+				log.Error("no post ID to preview")
+			}
+			return
 		case Create:
 			followingWords := strings.Join(commands[i+1:], "_")
 			doCreate(followingWords, "post")
@@ -84,7 +117,7 @@ func main() {
 			log.KV("command", arg).Error("unknown command")
 		}
 	}
-	fmt.Println(usage)
+	fmt.Print(usage + "\n")
 }
 
 func doInit() {
@@ -120,6 +153,46 @@ func doServer(isProd bool) {
 	log.Err(err).Fatal("failed to serve")
 }
 
+func doBuild(outdir string) {
+	brog, err := brogger.PrepareBrog(false)
+	if err != nil {
+		log.Err(err).Error("can't prepare brog")
+		return
+	}
+	defer closeOrPanic(brog)
+
+	if err := brog.Build(outdir); err != nil {
+		log.Err(err).Error("failed to build")
+		return
+	}
+}
+
+func doPreview(postID string, duration string) {
+	ttl := brogger.DefaultPreviewTTL
+	if duration != "" {
+		var err error
+		ttl, err = time.ParseDuration(duration)
+		if err != nil {
+			log.Err(err).KV("duration", duration).Error("invalid preview duration")
+			return
+		}
+	}
+
+	brog, err := brogger.PrepareBrog(false)
+	if err != nil {
+		log.Err(err).Error("can't prepare brog")
+		return
+	}
+	defer closeOrPanic(brog)
+
+	url, err := brog.PreviewURL(postID, ttl)
+	if err != nil {
+		log.Err(err).KV("post.id", postID).Error("can't create preview")
+		return
+	}
+	fmt.Println(url)
+}
+
 func doCreate(newPostFilename string, creationType string) {
 	brog, err := brogger.PrepareBrog(false)
 	if err != nil {